@@ -0,0 +1,67 @@
+package dyn
+
+import (
+	"context"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// AdvancedRedirect is an Advanced Redirect service attached to a node, with
+// its rules in the order they are evaluated.
+type AdvancedRedirect struct {
+	Zone   string                 `json:"zone,omitempty"`
+	FQDN   string                 `json:"fqdn,omitempty"`
+	Active string                 `json:"active"`
+	Rules  []AdvancedRedirectRule `json:"rules"`
+}
+
+// AdvancedRedirectRule redirects the requests matching a host prefix and a
+// path to a URL pattern.
+type AdvancedRedirectRule struct {
+	PublicID   string      `json:"public_id,omitempty"`
+	HostPrefix string      `json:"host_prefix"`
+	Path       string      `json:"path"`
+	URLPattern string      `json:"url_pattern"`
+	Code       looseString `json:"code"`
+	Active     string      `json:"active"`
+}
+
+// advancedRedirectRequest is the body of a request creating or updating an
+// Advanced Redirect service. Its rules replace those of the service, in
+// order. The zone is published separately, with notes.
+type advancedRedirectRequest struct {
+	Active  string                 `json:"active"`
+	Rules   []AdvancedRedirectRule `json:"rules"`
+	Publish string                 `json:"publish"`
+}
+
+type advancedRedirectResponse struct {
+	dynect.ResponseBlock
+	Data AdvancedRedirect `json:"data"`
+}
+
+// GetAdvancedRedirect reads the Advanced Redirect service of fqdn.
+func (c *Client) GetAdvancedRedirect(ctx context.Context, zone, fqdn string) (*AdvancedRedirect, error) {
+	var resp advancedRedirectResponse
+	if err := c.DoContext(ctx, "GET", "AdvRedirect/"+zone+"/"+fqdn+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateAdvancedRedirect stages an Advanced Redirect service at fqdn.
+func (c *Client) CreateAdvancedRedirect(ctx context.Context, zone, fqdn string, redirect *advancedRedirectRequest) error {
+	return c.DoContext(ctx, "POST", "AdvRedirect/"+zone+"/"+fqdn+"/", redirect, nil)
+}
+
+// UpdateAdvancedRedirect stages changes to the Advanced Redirect service of
+// fqdn, replacing its rules.
+func (c *Client) UpdateAdvancedRedirect(ctx context.Context, zone, fqdn string, redirect *advancedRedirectRequest) error {
+	return c.DoContext(ctx, "PUT", "AdvRedirect/"+zone+"/"+fqdn+"/", redirect, nil)
+}
+
+// DeleteAdvancedRedirect stages the deletion of the Advanced Redirect
+// service of fqdn, along with its rules and records.
+func (c *Client) DeleteAdvancedRedirect(ctx context.Context, zone, fqdn string) error {
+	return c.DoContext(ctx, "DELETE", "AdvRedirect/"+zone+"/"+fqdn+"/", nil, nil)
+}
@@ -0,0 +1,487 @@
+package dyn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+const (
+	defaultPollInterval = 1 * time.Second
+	defaultMaxWait      = 10 * time.Minute
+)
+
+// Client is a context-aware client for the DynECT REST API.
+//
+// Unlike dynect.Client it never blocks without a deadline: every request
+// honours the context it is given, and jobs promoted with a 307 are polled
+// every PollInterval for at most MaxWait.
+type Client struct {
+	CustomerName string
+	Token        string
+
+	// PollInterval is the delay between two polls of a pending job.
+	PollInterval time.Duration
+
+	// MaxWait bounds the time spent polling a single job. Zero means the
+	// job is polled until the request context is done.
+	MaxWait time.Duration
+
+	// ForeignChanges is what to do when a zone about to be published has
+	// unpublished changes that were not made by Terraform: one of
+	// ForeignChangesFail, ForeignChangesWarn or ForeignChangesProceed.
+	ForeignChanges string
+
+	// PublishNotes is the template of the note attached to each publish,
+	// see renderPublishNotes. No note is sent when it is empty.
+	PublishNotes string
+
+	// AutoPublish is whether changes are published as soon as they are
+	// staged. Zones listed in ManualPublishZones are never published
+	// automatically; their changes are left pending for review.
+	AutoPublish        bool
+	ManualPublishZones []string
+
+	baseURL    string
+	httpClient *http.Client
+	logger     hclog.Logger
+	stopCtx    context.Context
+	username   string
+}
+
+// NewClient returns a Client for the given customer that is not logged in.
+func NewClient(customerName string) *Client {
+	return &Client{
+		CustomerName:   customerName,
+		PollInterval:   defaultPollInterval,
+		MaxWait:        defaultMaxWait,
+		ForeignChanges: ForeignChangesWarn,
+		AutoPublish:    true,
+		baseURL:        dynect.DynAPIPrefix,
+		httpClient: &http.Client{
+			Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+			// Job redirects are polled by DoContext itself.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger:  hclog.NewNullLogger(),
+		stopCtx: context.Background(),
+	}
+}
+
+// SetLogger sets the logger used to trace the requests made by the client.
+func (c *Client) SetLogger(logger hclog.Logger) {
+	c.logger = logger
+}
+
+// StopContext returns the context that is cancelled once Terraform asks the
+// provider to stop.
+func (c *Client) StopContext() context.Context {
+	return c.stopCtx
+}
+
+// Login establishes a new session with the DynECT API.
+func (c *Client) Login(ctx context.Context, username, password string) error {
+	req := dynect.LoginBlock{
+		Username:     username,
+		Password:     password,
+		CustomerName: c.CustomerName,
+	}
+
+	var resp dynect.LoginResponse
+	if err := c.DoContext(ctx, "POST", "Session", req, &resp); err != nil {
+		return err
+	}
+
+	c.Token = resp.Data.Token
+	c.username = username
+	return nil
+}
+
+// LoggedIn reports whether the client holds a session token.
+func (c *Client) LoggedIn() bool {
+	return len(c.Token) > 0
+}
+
+// DoContext performs a request against the given endpoint and decodes the
+// response into responseData. Requests promoted to a job are polled until the
+// job completes, MaxWait elapses or ctx is done, whichever comes first.
+func (c *Client) DoContext(ctx context.Context, method, endpoint string, requestData, responseData interface{}) error {
+	if !c.LoggedIn() && !(method == "POST" && endpoint == "Session") {
+		return errors.New("Will not perform request; client is closed")
+	}
+
+	var body []byte
+	if requestData != nil {
+		var err error
+		body, err = json.Marshal(requestData)
+		if err != nil {
+			return err
+		}
+	}
+
+	urlStr := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+	resp, err := c.roundTrip(ctx, method, urlStr, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return decodeResponse(resp, responseData)
+	case http.StatusTemporaryRedirect:
+		loc := resp.Header.Get("Location")
+		return c.waitJob(ctx, jobIDFromLocation(loc), c.jobURL(loc), responseData)
+	case http.StatusTooManyRequests:
+		return dynect.ErrRateLimited
+	}
+
+	reason, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read in response body")
+	}
+	return fmt.Errorf("server responded with %v: %v", resp.Status, string(reason))
+}
+
+// isNotFound reports whether err is Dyn answering that the requested object
+// does not exist.
+func isNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "server responded with 404")
+}
+
+// Do performs a request with no deadline other than the provider stop
+// context.
+func (c *Client) Do(method, endpoint string, requestData, responseData interface{}) error {
+	return c.DoContext(c.stopCtx, method, endpoint, requestData, responseData)
+}
+
+// roundTrip sends a single request and traces it. The response body is
+// buffered, so it can be logged and still be read by the caller.
+func (c *Client) roundTrip(ctx context.Context, method, urlStr string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, urlStr, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Auth-Token", c.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	endpoint := strings.TrimPrefix(urlStr, c.baseURL+"/")
+	if c.logger.IsTrace() {
+		c.logger.Trace("request", "method", method, "endpoint", endpoint,
+			"headers", redactHeaders(req.Header), "body", redactBody(body))
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	duration := time.Since(start)
+	if err != nil {
+		c.logger.Debug("request failed", "method", method, "endpoint", endpoint,
+			"duration", duration, "error", err)
+		return nil, err
+	}
+
+	text, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("Could not read response body: %s", err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(text))
+
+	jobID := jobIDFromLocation(resp.Header.Get("Location"))
+	var block dynect.ResponseBlock
+	if jobID == 0 && json.Unmarshal(text, &block) == nil {
+		jobID = block.JobId
+	}
+
+	c.logger.Debug("response", "method", method, "endpoint", endpoint,
+		"status", resp.StatusCode, "duration", duration, "job_id", jobID)
+	if c.logger.IsTrace() {
+		c.logger.Trace("response body", "method", method, "endpoint", endpoint,
+			"headers", redactHeaders(resp.Header), "body", redactBody(text))
+	}
+
+	return resp, nil
+}
+
+func decodeResponse(resp *http.Response, responseData interface{}) error {
+	if resp.ContentLength == 0 {
+		log.Printf("[WARN] Dyn returned a zero-length response body; skipping decoding of response")
+		return nil
+	}
+
+	text, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("Could not read response body")
+	}
+
+	if responseData == nil {
+		return nil
+	}
+	if err := json.Unmarshal(text, responseData); err != nil {
+		return fmt.Errorf("Error unmarshalling response: %s", err)
+	}
+	return nil
+}
+
+// publishZoneBlock is dynect.PublishZoneBlock with the notes Dyn keeps in
+// the history of the zone.
+type publishZoneBlock struct {
+	Publish bool   `json:"publish"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+// PublishZone publishes a zone and the changes made in the current session,
+// recording notes in the history of the zone unless they are empty.
+func (c *Client) PublishZone(ctx context.Context, zone, notes string) error {
+	data := &publishZoneBlock{
+		Publish: true,
+		Notes:   notes,
+	}
+	return c.DoContext(ctx, "PUT", "Zone/"+zone, data, nil)
+}
+
+// GetRecordID finds the DNS record ID by fetching all records for a FQDN.
+func (c *Client) GetRecordID(ctx context.Context, record *dynect.Record) error {
+	finalID := ""
+	url := fmt.Sprintf("AllRecord/%s/%s", record.Zone, record.FQDN)
+	var records dynect.AllRecordsResponse
+	err := c.DoContext(ctx, "GET", url, nil, &records)
+	if err != nil {
+		return fmt.Errorf("Failed to find Dyn record id: %s", err)
+	}
+	for _, recordURL := range records.Data {
+		id := strings.TrimPrefix(recordURL, fmt.Sprintf("/REST/%sRecord/%s/%s/", record.Type, record.Zone, record.FQDN))
+		if !strings.Contains(id, "/") && id != "" {
+			finalID = id
+			log.Printf("[INFO] Found Dyn record ID: %s", id)
+		}
+	}
+	if finalID == "" {
+		return fmt.Errorf("Failed to find Dyn record id!")
+	}
+
+	record.ID = finalID
+	return nil
+}
+
+// GetNodeRecordTypes lists the type of each record at fqdn, once per record.
+// A node without records has none.
+func (c *Client) GetNodeRecordTypes(ctx context.Context, zone, fqdn string) ([]string, error) {
+	url := fmt.Sprintf("AllRecord/%s/%s", zone, fqdn)
+	var records dynect.AllRecordsResponse
+	err := c.DoContext(ctx, "GET", url, nil, &records)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var types []string
+	for _, recordURL := range records.Data {
+		// record URLs look like /REST/ARecord/zone/fqdn/id
+		kind := strings.SplitN(strings.TrimPrefix(recordURL, "/REST/"), "/", 2)[0]
+		if strings.HasSuffix(kind, "Record") {
+			types = append(types, strings.TrimSuffix(kind, "Record"))
+		}
+	}
+	return types, nil
+}
+
+// CreateRecord creates a DNS record.
+func (c *Client) CreateRecord(ctx context.Context, record *dynect.Record) error {
+	if record.FQDN == "" && record.Name == "" {
+		record.FQDN = record.Zone
+	} else if record.FQDN == "" {
+		record.FQDN = fmt.Sprintf("%s.%s", record.Name, record.Zone)
+	}
+	rdata, err := buildRData(record)
+	if err != nil {
+		return fmt.Errorf("Failed to create Dyn RData: %s", err)
+	}
+	url := fmt.Sprintf("%sRecord/%s/%s", record.Type, record.Zone, record.FQDN)
+	data := &dynect.RecordRequest{
+		RData: rdata,
+		TTL:   record.TTL,
+	}
+	return c.DoContext(ctx, "POST", url, data, nil)
+}
+
+// UpdateRecord updates a DNS record.
+func (c *Client) UpdateRecord(ctx context.Context, record *dynect.Record) error {
+	if record.FQDN == "" {
+		record.FQDN = fmt.Sprintf("%s.%s", record.Name, record.Zone)
+	}
+	rdata, err := buildRData(record)
+	if err != nil {
+		return fmt.Errorf("Failed to create Dyn RData: %s", err)
+	}
+	url := fmt.Sprintf("%sRecord/%s/%s/%s", record.Type, record.Zone, record.FQDN, record.ID)
+	data := &dynect.RecordRequest{
+		RData: rdata,
+		TTL:   record.TTL,
+	}
+	return c.DoContext(ctx, "PUT", url, data, nil)
+}
+
+// DeleteRecord deletes a DNS record.
+func (c *Client) DeleteRecord(ctx context.Context, record *dynect.Record) error {
+	if record.FQDN == "" {
+		record.FQDN = fmt.Sprintf("%s.%s", record.Name, record.Zone)
+	}
+	// safety check that we have an ID, otherwise we could accidentally delete everything
+	if record.ID == "" {
+		return fmt.Errorf("No ID found! We can't continue!")
+	}
+	url := fmt.Sprintf("%sRecord/%s/%s/%s", record.Type, record.Zone, record.FQDN, record.ID)
+	return c.DoContext(ctx, "DELETE", url, nil, nil)
+}
+
+// GetRecord fetches the details of a DNS record.
+func (c *Client) GetRecord(ctx context.Context, record *dynect.Record) error {
+	url := fmt.Sprintf("%sRecord/%s/%s/%s", record.Type, record.Zone, record.FQDN, record.ID)
+	var rec dynect.RecordResponse
+	err := c.DoContext(ctx, "GET", url, nil, &rec)
+	if err != nil {
+		return err
+	}
+
+	record.Zone = rec.Data.Zone
+	record.FQDN = rec.Data.FQDN
+	record.Name = strings.TrimSuffix(rec.Data.FQDN, "."+rec.Data.Zone)
+	record.Type = rec.Data.RecordType
+	record.TTL = strconv.Itoa(rec.Data.TTL)
+
+	value, err := recordValue(rec.Data.RecordType, rec.Data.RData)
+	if err != nil {
+		return err
+	}
+	record.Value = value
+
+	return nil
+}
+
+// recordValue renders the rdata of a record of the given type as the value
+// of a dyn_record. Types dyn_record cannot manage are rendered in the order
+// of their master file fields.
+func recordValue(recordType string, rdata dynect.DataBlock) (string, error) {
+	switch recordType {
+	case "A", "AAAA":
+		return rdata.Address, nil
+	case "ALIAS":
+		return rdata.Alias, nil
+	case "CNAME":
+		return rdata.CName, nil
+	case "MX":
+		return fmt.Sprintf("%d %s", rdata.Preference, rdata.Exchange), nil
+	case "NS":
+		return rdata.NSDName, nil
+	case "PTR":
+		return rdata.PTRDname, nil
+	case "SRV":
+		return fmt.Sprintf("%d %s %s %s", rdata.Priority, rdata.Weight, rdata.Port, rdata.Target), nil
+	case "SOA":
+		return rdata.RName, nil
+	case "TXT", "SPF":
+		return rdata.TxtData, nil
+	case "CERT":
+		return fmt.Sprintf("%s %s %s %s", rdata.Format, rdata.Tag, rdata.Algorithm, rdata.Certificate), nil
+	case "DHCID":
+		return rdata.Digest, nil
+	case "DNAME":
+		return rdata.DName, nil
+	case "DNSKEY", "KEY":
+		return fmt.Sprintf("%s %s %s %s", rdata.Flags, rdata.Protocol, rdata.Algorithm, rdata.PublicKey), nil
+	case "DS":
+		return fmt.Sprintf("%s %s %s %s", rdata.KeyTag, rdata.Algorithm, rdata.DigestType, rdata.Digest), nil
+	case "IPSECKEY":
+		return fmt.Sprintf("%s %s %s %s", rdata.Precendence, rdata.GatewayType, rdata.Algorithm, rdata.PublicKey), nil
+	case "KX":
+		return fmt.Sprintf("%d %s", rdata.Preference, rdata.Exchange), nil
+	case "LOC":
+		return fmt.Sprintf("%s %s %s %s %s %s", rdata.Latitude, rdata.Longitude, rdata.Altitude, rdata.Size, rdata.HorizPre, rdata.VertPre), nil
+	case "NAPTR":
+		return fmt.Sprintf("%s %d %q %q %q %s", rdata.Order, rdata.Preference, rdata.Flags, rdata.Services, rdata.Regexp, rdata.Replacement), nil
+	case "NSAP":
+		return rdata.NSAP, nil
+	case "PX":
+		return fmt.Sprintf("%d %s %s", rdata.Preference, rdata.Map822, rdata.MapX400), nil
+	case "RP":
+		return fmt.Sprintf("%s %s", rdata.Mbox, rdata.TxtDName), nil
+	case "SSHFP":
+		return fmt.Sprintf("%s %s %s", rdata.Algorithm, rdata.FPType, rdata.Fingerprint), nil
+	}
+	return "", fmt.Errorf("Invalid Dyn record type: %s", recordType)
+}
+
+func buildRData(r *dynect.Record) (dynect.DataBlock, error) {
+	var rdata dynect.DataBlock
+
+	switch r.Type {
+	case "A", "AAAA":
+		rdata = dynect.DataBlock{
+			Address: r.Value,
+		}
+	case "ALIAS":
+		rdata = dynect.DataBlock{
+			Alias: r.Value,
+		}
+	case "CNAME":
+		rdata = dynect.DataBlock{
+			CName: r.Value,
+		}
+	case "MX":
+		preference, exchange, err := parseMXValue(r.Value)
+		if err != nil {
+			return rdata, err
+		}
+		rdata = dynect.DataBlock{
+			Preference: preference,
+			Exchange:   exchange,
+		}
+	case "NS":
+		rdata = dynect.DataBlock{
+			NSDName: r.Value,
+		}
+	case "PTR":
+		rdata = dynect.DataBlock{
+			PTRDname: r.Value,
+		}
+	case "SRV":
+		priority, weight, port, target, err := parseSRVValue(r.Value)
+		if err != nil {
+			return rdata, err
+		}
+		rdata = dynect.DataBlock{
+			Priority: priority,
+			Weight:   strconv.Itoa(weight),
+			Port:     strconv.Itoa(port),
+			Target:   target,
+		}
+	case "SOA":
+		rdata = dynect.DataBlock{
+			RName: r.Value,
+		}
+	case "TXT", "SPF":
+		rdata = dynect.DataBlock{
+			TxtData: r.Value,
+		}
+	default:
+		return rdata, fmt.Errorf("Invalid Dyn record type: %s", r.Type)
+	}
+
+	return rdata, nil
+}
@@ -0,0 +1,178 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// testJobServer redirects every request to a job that reports "incomplete"
+// until it has been polled pollsUntilDone times.
+func testJobServer(pollsUntilDone int32) (*httptest.Server, *int32) {
+	var polls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/REST/Zone/example.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/REST/Job/1234")
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/REST/Job/1234", func(w http.ResponseWriter, r *http.Request) {
+		status := "incomplete"
+		if atomic.AddInt32(&polls, 1) >= pollsUntilDone {
+			status = "success"
+		}
+		fmt.Fprintf(w, `{"status": %q, "job_id": 1234, "data": {"zone": "example.com", "serial": 42}}`, status)
+	})
+	return httptest.NewServer(mux), &polls
+}
+
+func testClient(server *httptest.Server) *Client {
+	client := NewClient("terraform")
+	client.Token = "token"
+	client.PollInterval = time.Millisecond
+	client.baseURL = server.URL + "/REST"
+	return client
+}
+
+func TestClientDoContext_pollsJob(t *testing.T) {
+	server, polls := testJobServer(3)
+	defer server.Close()
+
+	client := testClient(server)
+
+	var resp dynect.ZoneResponse
+	if err := client.DoContext(context.Background(), "PUT", "Zone/example.com", nil, &resp); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if *polls != 3 {
+		t.Fatalf("expected 3 polls, got %d", *polls)
+	}
+	if resp.Data.Serial != 42 {
+		t.Fatalf("expected serial 42, got %d", resp.Data.Serial)
+	}
+}
+
+func TestClientDoContext_maxWait(t *testing.T) {
+	server, _ := testJobServer(1 << 30)
+	defer server.Close()
+
+	client := testClient(server)
+	client.MaxWait = 20 * time.Millisecond
+
+	err := client.DoContext(context.Background(), "PUT", "Zone/example.com", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "gave up waiting") {
+		t.Fatalf("expected a timeout error, got: %v", err)
+	}
+}
+
+func TestClientDoContext_cancel(t *testing.T) {
+	server, _ := testJobServer(1 << 30)
+	defer server.Close()
+
+	client := testClient(server)
+	client.MaxWait = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	err := client.DoContext(ctx, "PUT", "Zone/example.com", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Fatalf("expected a cancellation error, got: %v", err)
+	}
+}
+
+func TestClientDoContext_loggedOut(t *testing.T) {
+	client := NewClient("terraform")
+
+	err := client.DoContext(context.Background(), "GET", "Zone/example.com", nil, nil)
+	if err == nil {
+		t.Fatal("expected an error for a client without a session")
+	}
+}
+
+func TestClientDoContext_pendingJobID(t *testing.T) {
+	server, _ := testJobServer(1 << 30)
+	defer server.Close()
+
+	client := testClient(server)
+	client.MaxWait = 20 * time.Millisecond
+
+	err := client.DoContext(context.Background(), "PUT", "Zone/example.com", nil, nil)
+	if id := pendingJobID(err); id != 1234 {
+		t.Fatalf("expected pending job 1234, got %d (err: %v)", id, err)
+	}
+	if !strings.Contains(err.Error(), "1234") {
+		t.Fatalf("expected the job ID in the error, got: %s", err)
+	}
+}
+
+func TestClientGetJob(t *testing.T) {
+	server, _ := testJobServer(2)
+	defer server.Close()
+
+	client := testClient(server)
+
+	var resp dynect.ZoneResponse
+	job, err := client.GetJob(context.Background(), 1234, &resp)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if job.Status != "incomplete" || resp.Data.Serial != 0 {
+		t.Fatalf("expected an incomplete job, got %#v", job)
+	}
+
+	job, err = client.GetJob(context.Background(), 1234, &resp)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if job.Status != "success" || job.ID != 1234 || resp.Data.Serial != 42 {
+		t.Fatalf("expected a successful job, got %#v", job)
+	}
+}
+
+func TestClientGetNodeRecordTypes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/REST/AllRecord/example.com/www.example.com", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "success", "data": [
+			"/REST/ARecord/example.com/www.example.com/1",
+			"/REST/ARecord/example.com/www.example.com/2",
+			"/REST/TXTRecord/example.com/www.example.com/3"
+		]}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := testClient(server)
+	types, err := client.GetNodeRecordTypes(context.Background(), "example.com", "www.example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual, expected := strings.Join(types, ","), "A,A,TXT"; actual != expected {
+		t.Fatalf("expected types %q, got %q", expected, actual)
+	}
+
+	types, err = client.GetNodeRecordTypes(context.Background(), "example.com", "mail.example.com")
+	if err != nil || len(types) != 0 {
+		t.Fatalf("expected no records at a missing node, got %v, %v", types, err)
+	}
+}
+
+func TestBuildRData_MX(t *testing.T) {
+	rdata, err := buildRData(&dynect.Record{Type: "MX", Value: "10 mx.terraform.io."})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if rdata.Preference != 10 || rdata.Exchange != "mx.terraform.io." {
+		t.Fatalf("unexpected rdata: %#v", rdata)
+	}
+
+	if _, err := buildRData(&dynect.Record{Type: "MX", Value: "mx.terraform.io"}); err == nil {
+		t.Fatal("expected an error for an MX value without a preference")
+	}
+}
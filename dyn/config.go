@@ -1,27 +1,56 @@
 package dyn
 
 import (
+	"context"
 	"fmt"
 	"log"
-
-	"github.com/hashicorp/terraform/helper/logging"
-	"github.com/nesv/go-dynect/dynect"
+	"time"
 )
 
 type Config struct {
 	CustomerName string
 	Username     string
 	Password     string
+	PollInterval time.Duration
+	MaxWait      time.Duration
+
+	// ForeignChanges is what to do with unpublished changes that were not
+	// made by Terraform.
+	ForeignChanges string
+
+	// PublishNotes is the template of the note attached to each publish.
+	PublishNotes string
+
+	// AutoPublish is whether changes are published as soon as they are
+	// made, except in ManualPublishZones.
+	AutoPublish        bool
+	ManualPublishZones []string
+
+	// StopContext is cancelled when Terraform asks the provider to stop.
+	StopContext context.Context
 }
 
 // Client() returns a new client for accessing dyn.
-func (c *Config) Client() (*dynect.ConvenientClient, error) {
-	client := dynect.NewConvenientClient(c.CustomerName)
-	if logging.IsDebugOrHigher() {
-		client.Verbose(true)
+func (c *Config) Client() (*Client, error) {
+	client := NewClient(c.CustomerName)
+	client.SetLogger(newHTTPLogger())
+	if c.PollInterval > 0 {
+		client.PollInterval = c.PollInterval
+	}
+	if c.MaxWait > 0 {
+		client.MaxWait = c.MaxWait
+	}
+	if c.ForeignChanges != "" {
+		client.ForeignChanges = c.ForeignChanges
+	}
+	client.PublishNotes = c.PublishNotes
+	client.AutoPublish = c.AutoPublish
+	client.ManualPublishZones = c.ManualPublishZones
+	if c.StopContext != nil {
+		client.stopCtx = c.StopContext
 	}
 
-	err := client.Login(c.Username, c.Password)
+	err := client.Login(client.StopContext(), c.Username, c.Password)
 	if err != nil {
 		return nil, fmt.Errorf("Error setting up Dyn client: %s", err)
 	}
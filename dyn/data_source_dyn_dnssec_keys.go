@@ -0,0 +1,114 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceDynDNSSECKeys() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDynDNSSECKeysRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flags": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"algorithm": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"public_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key_tag": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"dnskey": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"ds_records": dsRecordsSchema(),
+		},
+	}
+}
+
+func dataSourceDynDNSSECKeysRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	config, err := client.GetDNSSEC(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("Failed to read DNSSEC for Dyn zone %s: %s", zone, err)
+	}
+
+	d.SetId(zone)
+	if err := d.Set("keys", flattenDNSSECKeys(zone, config.Keys)); err != nil {
+		return err
+	}
+	return d.Set("ds_records", flattenDSRecords(zone, config.Keys))
+}
+
+// flattenDNSSECKeys lists the DNSKEY records of the active keys of a zone.
+func flattenDNSSECKeys(zone string, keys []DNSSECKey) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, key := range keys {
+		if key.Active == "N" {
+			continue
+		}
+		dnskey := key.DNSKey.DataBlock
+		wire, algorithm, err := dnskeyWire(dnskey)
+		if err != nil {
+			log.Printf("[WARN] Failed to decode the DNSKEY record of %s %s of Dyn zone %s: %s", key.Type, key.ID, zone, err)
+			continue
+		}
+		flags, _ := strconv.Atoi(dnskey.Flags)
+		protocol, _ := strconv.Atoi(dnskey.Protocol)
+
+		flattened = append(flattened, map[string]interface{}{
+			"type":       key.Type,
+			"flags":      flags,
+			"protocol":   protocol,
+			"algorithm":  algorithm,
+			"public_key": dnskey.PublicKey,
+			"key_tag":    dnskeyTag(wire),
+			"dnskey":     fmt.Sprintf("%d %d %d %s", flags, protocol, algorithm, dnskey.PublicKey),
+		})
+	}
+	return flattened
+}
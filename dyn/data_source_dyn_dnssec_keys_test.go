@@ -0,0 +1,36 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDynDNSSECKeys_basic(t *testing.T) {
+	zone := os.Getenv("DYN_SIGNED_ZONE")
+	if zone == "" {
+		t.Skip("DYN_SIGNED_ZONE must be set to a zone signed with DNSSEC for this acceptance test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynDNSSECKeysConfig, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.dyn_dnssec_keys.foobar", "keys.0.public_key"),
+					resource.TestCheckResourceAttrSet("data.dyn_dnssec_keys.foobar", "keys.0.key_tag"),
+					resource.TestCheckResourceAttr("data.dyn_dnssec_keys.foobar", "ds_records.0.digest_type", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckDynDNSSECKeysConfig = `
+data "dyn_dnssec_keys" "foobar" {
+	zone = "%s"
+}`
@@ -0,0 +1,64 @@
+package dyn
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceDynZoneExport() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDynZoneExportRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"zone_file": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"record_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDynZoneExportRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	records, err := client.getZoneRecordDetails(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("Failed to list the records of Dyn zone %s: %s", zone, err)
+	}
+
+	file, count := renderZoneFile(zone, records)
+	sum := sha256.Sum256([]byte(file))
+
+	d.SetId(zone)
+	d.Set("zone_file", file)
+	d.Set("record_count", count)
+	d.Set("sha256", hex.EncodeToString(sum[:]))
+	return nil
+}
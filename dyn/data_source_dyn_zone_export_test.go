@@ -0,0 +1,57 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDynZoneExport_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneExportConfig_record, zone),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneExportConfig_export, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.dyn_zone_export.foobar", "record_count"),
+					resource.TestCheckResourceAttrSet("data.dyn_zone_export.foobar", "sha256"),
+					resource.TestMatchResourceAttr("data.dyn_zone_export.foobar", "zone_file",
+						regexp.MustCompile(`(?m)^terraform-export\t300\tIN\tA\t192\.168\.0\.10$`)),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckDynZoneExportConfig_record = `
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform-export"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 300
+}`
+
+const testAccCheckDynZoneExportConfig_export = `
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform-export"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 300
+}
+
+data "dyn_zone_export" "foobar" {
+	zone = "${dyn_record.foobar.zone}"
+	depends_on = ["dyn_record.foobar"]
+}`
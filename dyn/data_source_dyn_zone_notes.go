@@ -0,0 +1,102 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceDynZoneNotes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDynZoneNotesRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validateIntAtLeast(1),
+			},
+
+			"notes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"note": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDynZoneNotesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	notes, err := client.GetZoneNotes(ctx, zone, d.Get("limit").(int), 0)
+	if err != nil {
+		return fmt.Errorf("Failed to read the notes of Dyn zone %s: %s", zone, err)
+	}
+
+	d.SetId(zone)
+	return d.Set("notes", flattenZoneNotes(notes))
+}
+
+func flattenZoneNotes(notes []ZoneNote) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(notes))
+	for i, n := range notes {
+		serial, _ := strconv.Atoi(string(n.Serial))
+		result[i] = map[string]interface{}{
+			"serial":    serial,
+			"type":      n.Type,
+			"user_name": n.UserName,
+			"timestamp": zoneNoteTimestamp(string(n.Timestamp)),
+			"note":      n.Note,
+		}
+	}
+	return result
+}
+
+// zoneNoteTimestamp formats the Unix time of a note as RFC 3339, and leaves
+// any other timestamp as Dyn returned it.
+func zoneNoteTimestamp(ts string) string {
+	if secs, err := strconv.ParseInt(ts, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC().Format(time.RFC3339)
+	}
+	return ts
+}
@@ -0,0 +1,69 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDynZoneNotes_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneNotesConfig_record, zone),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneNotesConfig_notes, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dyn_zone_notes.foobar", "notes.#", "1"),
+					resource.TestCheckResourceAttr("data.dyn_zone_notes.foobar", "notes.0.note", "terraform-acc-test dyn_record A terraform-notes."+zone),
+				),
+			},
+		},
+	})
+}
+
+func TestZoneNoteTimestamp(t *testing.T) {
+	cases := map[string]string{
+		"1539907200":           "2018-10-19T00:00:00Z",
+		"2018-10-19T00:00:00Z": "2018-10-19T00:00:00Z",
+		"":                     "",
+	}
+	for ts, expected := range cases {
+		if actual := zoneNoteTimestamp(ts); actual != expected {
+			t.Errorf("zoneNoteTimestamp(%q): expected %q, got %q", ts, expected, actual)
+		}
+	}
+}
+
+const testAccCheckDynZoneNotesConfig_record = `
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform-notes"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+	publish_notes = "terraform-acc-test {{.Resource}}"
+}`
+
+const testAccCheckDynZoneNotesConfig_notes = `
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform-notes"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+	publish_notes = "terraform-acc-test {{.Resource}}"
+}
+
+data "dyn_zone_notes" "foobar" {
+	zone = "%s"
+	limit = 1
+}`
@@ -0,0 +1,105 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// DDNS is a Dynamic DNS service, letting the hosts of a node update the
+// address of its record.
+type DDNS struct {
+	Zone        string      `json:"zone,omitempty"`
+	FQDN        string      `json:"fqdn,omitempty"`
+	RecordType  string      `json:"record_type,omitempty"`
+	Address     string      `json:"address"`
+	Active      string      `json:"active"`
+	AbuseCount  looseString `json:"abuse_count"`
+	LastUpdated looseString `json:"last_updated"`
+}
+
+// ddnsRequest is the body of a request creating or updating a Dynamic DNS
+// service. The zone is published separately, with notes.
+type ddnsRequest struct {
+	Address string `json:"address,omitempty"`
+	Active  string `json:"active,omitempty"`
+	Publish string `json:"publish"`
+}
+
+type ddnsResponse struct {
+	dynect.ResponseBlock
+	Data DDNS `json:"data"`
+}
+
+func ddnsURL(service, zone, fqdn, recordType string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/", service, zone, fqdn, recordType)
+}
+
+// GetDDNS reads the Dynamic DNS service of the recordType record of fqdn.
+func (c *Client) GetDDNS(ctx context.Context, zone, fqdn, recordType string) (*DDNS, error) {
+	var resp ddnsResponse
+	if err := c.DoContext(ctx, "GET", ddnsURL("DDNS", zone, fqdn, recordType), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateDDNS stages a Dynamic DNS service for the recordType record of
+// fqdn.
+func (c *Client) CreateDDNS(ctx context.Context, zone, fqdn, recordType string, ddns *ddnsRequest) error {
+	return c.DoContext(ctx, "POST", ddnsURL("DDNS", zone, fqdn, recordType), ddns, nil)
+}
+
+// UpdateDDNS stages changes to the Dynamic DNS service of the recordType
+// record of fqdn.
+func (c *Client) UpdateDDNS(ctx context.Context, zone, fqdn, recordType string, ddns *ddnsRequest) error {
+	return c.DoContext(ctx, "PUT", ddnsURL("DDNS", zone, fqdn, recordType), ddns, nil)
+}
+
+// DeleteDDNS stages the deletion of the Dynamic DNS service of the
+// recordType record of fqdn.
+func (c *Client) DeleteDDNS(ctx context.Context, zone, fqdn, recordType string) error {
+	return c.DoContext(ctx, "DELETE", ddnsURL("DDNS", zone, fqdn, recordType), nil, nil)
+}
+
+// DDNSHost is a user a host authenticates as to update a Dynamic DNS
+// service. Dyn only returns the password of the user when it creates it.
+type DDNSHost struct {
+	Zone       string `json:"zone,omitempty"`
+	FQDN       string `json:"fqdn,omitempty"`
+	RecordType string `json:"record_type,omitempty"`
+	UserName   string `json:"user_name"`
+	Password   string `json:"password,omitempty"`
+}
+
+type ddnsHostResponse struct {
+	dynect.ResponseBlock
+	Data DDNSHost `json:"data"`
+}
+
+// CreateDDNSHost creates a user allowed to update the Dynamic DNS service of
+// the recordType record of fqdn, with a generated password.
+func (c *Client) CreateDDNSHost(ctx context.Context, zone, fqdn, recordType string) (*DDNSHost, error) {
+	var resp ddnsHostResponse
+	if err := c.DoContext(ctx, "POST", ddnsURL("DDNSHost", zone, fqdn, recordType), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetDDNSHost reads a user of the Dynamic DNS service of the recordType
+// record of fqdn.
+func (c *Client) GetDDNSHost(ctx context.Context, zone, fqdn, recordType, userName string) (*DDNSHost, error) {
+	var resp ddnsHostResponse
+	if err := c.DoContext(ctx, "GET", ddnsURL("DDNSHost", zone, fqdn, recordType)+userName+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// DeleteDDNSHost deletes a user of the Dynamic DNS service of the recordType
+// record of fqdn.
+func (c *Client) DeleteDDNSHost(ctx context.Context, zone, fqdn, recordType, userName string) error {
+	return c.DoContext(ctx, "DELETE", ddnsURL("DDNSHost", zone, fqdn, recordType)+userName+"/", nil, nil)
+}
@@ -0,0 +1,47 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDDNS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/REST/DDNS/example.com/office.example.com/A/":
+			fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "fqdn": "office.example.com", "record_type": "A",
+				"address": "192.168.0.20", "active": "Y", "abuse_count": 2, "last_updated": 1539907200}}`)
+		case r.Method == "POST" && r.URL.Path == "/REST/DDNSHost/example.com/office.example.com/A/":
+			fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "fqdn": "office.example.com", "record_type": "A",
+				"user_name": "ddns-office", "password": "s3cret"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	ddns, err := client.GetDDNS(context.Background(), "example.com", "office.example.com", "A")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ddns.Address != "192.168.0.20" || ddns.AbuseCount != "2" || ddns.LastUpdated != "1539907200" {
+		t.Fatalf("unexpected service: %#v", ddns)
+	}
+
+	host, err := client.CreateDDNSHost(context.Background(), "example.com", "office.example.com", "A")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if host.UserName != "ddns-office" || host.Password != "s3cret" {
+		t.Fatalf("unexpected host: %#v", host)
+	}
+
+	_, err = client.GetDDNSHost(context.Background(), "example.com", "office.example.com", "A", "gone")
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package dyn
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// dnssecAlgorithms are the signing algorithms Dyn supports, with their DNS
+// security algorithm numbers.
+var dnssecAlgorithms = map[string]int{
+	"DSA":         3,
+	"RSA/SHA-1":   5,
+	"RSA/SHA-256": 8,
+	"RSA/SHA-512": 10,
+}
+
+// dnssecNotifyEvents are the events Dyn notifies the contact of.
+var dnssecNotifyEvents = []string{"create", "expire", "warning"}
+
+// DNSSECKey is a KSK or ZSK of a zone signed by Dyn.
+type DNSSECKey struct {
+	ID        looseString     `json:"dnssec_key_id,omitempty"`
+	Type      string          `json:"type"`
+	Algorithm string          `json:"algorithm"`
+	Bits      looseString     `json:"bits"`
+	Lifetime  looseString     `json:"lifetime,omitempty"`
+	Overlap   looseString     `json:"overlap,omitempty"`
+	StartTS   looseString     `json:"start_ts,omitempty"`
+	ExpireTS  looseString     `json:"expire_ts,omitempty"`
+	Active    string          `json:"active,omitempty"`
+	DNSKey    zoneRecordRData `json:"dnskey,omitempty"`
+}
+
+// DNSSEC is the signing configuration of a zone.
+type DNSSEC struct {
+	Zone            string      `json:"zone,omitempty"`
+	Active          string      `json:"active,omitempty"`
+	ContactNickname string      `json:"contact_nickname"`
+	NotifyEvents    string      `json:"notify_events,omitempty"`
+	Keys            []DNSSECKey `json:"keys"`
+}
+
+// dnssecRequest is the body of a request configuring the signing of a zone.
+type dnssecRequest struct {
+	ContactNickname string             `json:"contact_nickname"`
+	NotifyEvents    string             `json:"notify_events"`
+	Keys            []dnssecKeyRequest `json:"keys"`
+}
+
+// dnssecKeyRequest describes a key Dyn should sign a zone with.
+type dnssecKeyRequest struct {
+	Type      string `json:"type"`
+	Algorithm string `json:"algorithm"`
+	Bits      string `json:"bits"`
+	Lifetime  string `json:"lifetime,omitempty"`
+}
+
+type dnssecResponse struct {
+	dynect.ResponseBlock
+	Data DNSSEC `json:"data"`
+}
+
+// GetDNSSEC reads the signing configuration of a zone.
+func (c *Client) GetDNSSEC(ctx context.Context, zone string) (*DNSSEC, error) {
+	var resp dnssecResponse
+	if err := c.DoContext(ctx, "GET", "DNSSEC/"+zone+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateDNSSEC sets up the signing of a zone, which stays inactive until it
+// is activated.
+func (c *Client) CreateDNSSEC(ctx context.Context, zone string, config *dnssecRequest) error {
+	return c.DoContext(ctx, "POST", "DNSSEC/"+zone+"/", config, nil)
+}
+
+// UpdateDNSSEC changes the signing configuration of a zone. Dyn rolls the
+// keys over when their algorithm or length changes.
+func (c *Client) UpdateDNSSEC(ctx context.Context, zone string, config *dnssecRequest) error {
+	return c.DoContext(ctx, "PUT", "DNSSEC/"+zone+"/", config, nil)
+}
+
+// ActivateDNSSEC starts or stops the signing of a zone.
+func (c *Client) ActivateDNSSEC(ctx context.Context, zone string, active bool) error {
+	data := map[string]bool{"deactivate": true}
+	if active {
+		data = map[string]bool{"activate": true}
+	}
+	return c.DoContext(ctx, "PUT", "DNSSEC/"+zone+"/", data, nil)
+}
+
+// DeleteDNSSEC stops the signing of a zone and deletes its keys.
+func (c *Client) DeleteDNSSEC(ctx context.Context, zone string) error {
+	return c.DoContext(ctx, "DELETE", "DNSSEC/"+zone+"/", nil, nil)
+}
+
+// dsRecord is a DS record delegating to a key, with a SHA-256 digest.
+type dsRecord struct {
+	KeyTag     int
+	Algorithm  int
+	DigestType int
+	Digest     string
+}
+
+// String renders the DS record in master file syntax.
+func (ds dsRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest)
+}
+
+// dnskeyWire encodes the rdata of a DNSKEY record as in RFC 4034.
+func dnskeyWire(key dynect.DataBlock) ([]byte, int, error) {
+	flags, err := strconv.Atoi(key.Flags)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid flags %q", key.Flags)
+	}
+	protocol, err := strconv.Atoi(key.Protocol)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid protocol %q", key.Protocol)
+	}
+	algorithm, err := strconv.Atoi(key.Algorithm)
+	if err != nil {
+		var ok bool
+		if algorithm, ok = dnssecAlgorithms[key.Algorithm]; !ok {
+			return nil, 0, fmt.Errorf("invalid algorithm %q", key.Algorithm)
+		}
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(key.PublicKey), ""))
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid public key: %s", err)
+	}
+
+	wire := make([]byte, 4, 4+len(publicKey))
+	binary.BigEndian.PutUint16(wire, uint16(flags))
+	wire[2] = byte(protocol)
+	wire[3] = byte(algorithm)
+	return append(wire, publicKey...), algorithm, nil
+}
+
+// dnskeyTag computes the key tag of a DNSKEY record from its rdata, as in
+// RFC 4034 appendix B.
+func dnskeyTag(wire []byte) int {
+	var sum uint32
+	for i, b := range wire {
+		if i%2 == 0 {
+			sum += uint32(b) << 8
+		} else {
+			sum += uint32(b)
+		}
+	}
+	sum += sum >> 16 & 0xFFFF
+	return int(sum & 0xFFFF)
+}
+
+// dnskeyDS computes the DS record with a SHA-256 digest delegating zone to
+// key, as in RFC 4509.
+func dnskeyDS(zone string, key dynect.DataBlock) (dsRecord, error) {
+	wire, algorithm, err := dnskeyWire(key)
+	if err != nil {
+		return dsRecord{}, err
+	}
+
+	var owner []byte
+	for _, label := range strings.Split(strings.ToLower(strings.TrimSuffix(zone, ".")), ".") {
+		owner = append(owner, byte(len(label)))
+		owner = append(owner, label...)
+	}
+	owner = append(owner, 0)
+
+	sum := sha256.Sum256(append(owner, wire...))
+	return dsRecord{
+		KeyTag:     dnskeyTag(wire),
+		Algorithm:  algorithm,
+		DigestType: 2,
+		Digest:     strings.ToUpper(hex.EncodeToString(sum[:])),
+	}, nil
+}
@@ -0,0 +1,101 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func TestDNSKeyDS(t *testing.T) {
+	// the example of RFC 4509 section 2.3
+	key := dynect.DataBlock{
+		Flags:     "256",
+		Protocol:  "3",
+		Algorithm: "5",
+		PublicKey: "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw==",
+	}
+
+	ds, err := dnskeyDS("dskey.example.com.", key)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := "60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"
+	if ds.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, ds.String())
+	}
+}
+
+func TestDNSKeyDS_invalid(t *testing.T) {
+	cases := []dynect.DataBlock{
+		{Flags: "x", Protocol: "3", Algorithm: "8", PublicKey: "AQAB"},
+		{Flags: "257", Protocol: "3", Algorithm: "ED448?", PublicKey: "AQAB"},
+		{Flags: "257", Protocol: "3", Algorithm: "8", PublicKey: "not base64!"},
+	}
+	for _, key := range cases {
+		if _, err := dnskeyDS("example.com", key); err == nil {
+			t.Errorf("expected an error for %#v", key)
+		}
+	}
+}
+
+func TestGetDNSSEC(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/DNSSEC/dskey.example.com/" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status": "failure", "msgs": [{"INFO": "zone: No such DNSSEC configuration"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {
+			"zone": "dskey.example.com", "active": "Y", "contact_nickname": "owner", "notify_events": "create,expire",
+			"keys": [
+				{"dnssec_key_id": 1, "type": "KSK", "algorithm": "RSA/SHA-1", "bits": 2048, "active": "Y",
+				 "dnskey": {"flags": 256, "protocol": 3, "algorithm": 5, "public_key": "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="}},
+				{"dnssec_key_id": "2", "type": "ZSK", "algorithm": "RSA/SHA-1", "bits": "1024", "active": "Y", "dnskey": {}}
+			]
+		}}`)
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	config, err := client.GetDNSSEC(context.Background(), "dskey.example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if config.ContactNickname != "owner" || len(config.Keys) != 2 || config.Keys[0].Bits != "2048" {
+		t.Fatalf("unexpected configuration: %#v", config)
+	}
+
+	ds := flattenDSRecords("dskey.example.com", config.Keys)
+	if len(ds) != 1 || ds[0]["key_tag"] != 60485 {
+		t.Fatalf("unexpected DS records: %#v", ds)
+	}
+
+	_, err = client.GetDNSSEC(context.Background(), "unsigned.example.com")
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestFlattenDNSSECKeys(t *testing.T) {
+	keys := []DNSSECKey{
+		{Type: "KSK", Active: "Y"},
+		{Type: "ZSK", Active: "N"},
+	}
+	keys[0].DNSKey.Flags = "256"
+	keys[0].DNSKey.Protocol = "3"
+	keys[0].DNSKey.Algorithm = "RSA/SHA-1"
+	keys[0].DNSKey.PublicKey = "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+	flattened := flattenDNSSECKeys("dskey.example.com", keys)
+	if len(flattened) != 1 {
+		t.Fatalf("expected the active key only, got %#v", flattened)
+	}
+	if flattened[0]["key_tag"] != 60485 || flattened[0]["algorithm"] != 5 || flattened[0]["flags"] != 256 {
+		t.Fatalf("unexpected key: %#v", flattened[0])
+	}
+}
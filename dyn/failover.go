@@ -0,0 +1,56 @@
+package dyn
+
+import (
+	"context"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// ActiveFailover is an Active Failover service attached to a node, which
+// serves the primary address while it is healthy and the failover data
+// otherwise. notify_events is sent even when empty, as Dyn keeps the current
+// value of a field left out of an update.
+type ActiveFailover struct {
+	Zone            string         `json:"zone,omitempty"`
+	FQDN            string         `json:"fqdn,omitempty"`
+	Status          string         `json:"status,omitempty"`
+	Address         string         `json:"address"`
+	FailoverMode    string         `json:"failover_mode"`
+	FailoverData    string         `json:"failover_data"`
+	ContactNickname string         `json:"contact_nickname"`
+	TTL             looseString    `json:"ttl"`
+	AutoRecover     string         `json:"auto_recover"`
+	NotifyEvents    string         `json:"notify_events"`
+	Monitor         ServiceMonitor `json:"monitor"`
+	Publish         string         `json:"publish,omitempty"`
+}
+
+type activeFailoverResponse struct {
+	dynect.ResponseBlock
+	Data ActiveFailover `json:"data"`
+}
+
+// GetActiveFailover reads the Active Failover service of fqdn.
+func (c *Client) GetActiveFailover(ctx context.Context, zone, fqdn string) (*ActiveFailover, error) {
+	var resp activeFailoverResponse
+	if err := c.DoContext(ctx, "GET", "Failover/"+zone+"/"+fqdn+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateActiveFailover stages an Active Failover service at fqdn.
+func (c *Client) CreateActiveFailover(ctx context.Context, zone, fqdn string, failover *ActiveFailover) error {
+	return c.DoContext(ctx, "POST", "Failover/"+zone+"/"+fqdn+"/", failover, nil)
+}
+
+// UpdateActiveFailover stages changes to the Active Failover service of fqdn.
+func (c *Client) UpdateActiveFailover(ctx context.Context, zone, fqdn string, failover *ActiveFailover) error {
+	return c.DoContext(ctx, "PUT", "Failover/"+zone+"/"+fqdn+"/", failover, nil)
+}
+
+// DeleteActiveFailover stages the deletion of the Active Failover service of
+// fqdn, along with its records.
+func (c *Client) DeleteActiveFailover(ctx context.Context, zone, fqdn string) error {
+	return c.DoContext(ctx, "DELETE", "Failover/"+zone+"/"+fqdn+"/", nil, nil)
+}
@@ -0,0 +1,55 @@
+package dyn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetActiveFailover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/Failover/example.com/www.example.com/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "fqdn": "www.example.com",
+			"status": "failover", "address": "192.168.0.10", "failover_mode": "cname",
+			"failover_data": "standby.example.com", "contact_nickname": "owner", "ttl": "30",
+			"auto_recover": "N", "notify_events": "ip",
+			"monitor": {"protocol": "TCP", "interval": "1", "port": 443}}}`)
+	}))
+	defer server.Close()
+
+	failover, err := testClient(server).GetActiveFailover(context.Background(), "example.com", "www.example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if failover.Status != "failover" || failover.FailoverData != "standby.example.com" || looseInt(failover.TTL) != 30 {
+		t.Fatalf("unexpected service: %#v", failover)
+	}
+	if looseInt(failover.Monitor.Interval) != 1 || looseInt(failover.Monitor.Port) != 443 {
+		t.Fatalf("unexpected monitor: %#v", failover.Monitor)
+	}
+
+	_, err = testClient(server).GetActiveFailover(context.Background(), "example.com", "api.example.com")
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestActiveFailover_clearedFields(t *testing.T) {
+	// fields left out of an update keep their value on Dyn's side
+	body, err := json.Marshal(ActiveFailover{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, field := range []string{`"notify_events":""`} {
+		if !strings.Contains(string(body), field) {
+			t.Errorf("expected %s in %s", field, body)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package dyn
+
+import (
+	"context"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// gslbRegionCodes are the regions a GSLB service can serve separately.
+var gslbRegionCodes = []string{"global", "US East", "US West", "US Central", "Asia", "EU West", "EU Central", "EU East"}
+
+// gslbServeModes are how a GSLB service uses an address of a pool.
+var gslbServeModes = []string{"always", "obey", "remove", "no"}
+
+// GSLB is a global server load balancing service attached to a node. Fields
+// that can be cleared are sent even when empty, as Dyn keeps the current
+// value of a field left out of an update.
+type GSLB struct {
+	Zone            string         `json:"zone,omitempty"`
+	FQDN            string         `json:"fqdn,omitempty"`
+	Status          string         `json:"status,omitempty"`
+	ContactNickname string         `json:"contact_nickname"`
+	TTL             looseString    `json:"ttl"`
+	AutoRecover     string         `json:"auto_recover"`
+	NotifyEvents    string         `json:"notify_events"`
+	SyslogServer    string         `json:"syslog_server"`
+	SyslogPort      looseString    `json:"syslog_port,omitempty"`
+	SyslogIdent     string         `json:"syslog_ident,omitempty"`
+	SyslogFacility  string         `json:"syslog_facility,omitempty"`
+	Monitor         ServiceMonitor `json:"monitor"`
+	Region          []GSLBRegion   `json:"region"`
+	Publish         string         `json:"publish,omitempty"`
+}
+
+// GSLBRegion is the pool of addresses a GSLB service serves to a region.
+type GSLBRegion struct {
+	RegionCode   string        `json:"region_code"`
+	ServeCount   looseString   `json:"serve_count"`
+	FailoverMode string        `json:"failover_mode"`
+	FailoverData string        `json:"failover_data"`
+	Pool         []GSLBAddress `json:"pool"`
+}
+
+// GSLBAddress is an address of the pool of a region, with the health Dyn
+// last found it in.
+type GSLBAddress struct {
+	Address   string      `json:"address"`
+	Label     string      `json:"label"`
+	Weight    looseString `json:"weight"`
+	ServeMode string      `json:"serve_mode"`
+	Status    string      `json:"status,omitempty"`
+}
+
+type gslbResponse struct {
+	dynect.ResponseBlock
+	Data GSLB `json:"data"`
+}
+
+// GetGSLB reads the GSLB service of fqdn.
+func (c *Client) GetGSLB(ctx context.Context, zone, fqdn string) (*GSLB, error) {
+	var resp gslbResponse
+	if err := c.DoContext(ctx, "GET", "GSLB/"+zone+"/"+fqdn+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateGSLB stages a GSLB service at fqdn.
+func (c *Client) CreateGSLB(ctx context.Context, zone, fqdn string, gslb *GSLB) error {
+	return c.DoContext(ctx, "POST", "GSLB/"+zone+"/"+fqdn+"/", gslb, nil)
+}
+
+// UpdateGSLB stages changes to the GSLB service of fqdn, replacing its
+// regions and their pools.
+func (c *Client) UpdateGSLB(ctx context.Context, zone, fqdn string, gslb *GSLB) error {
+	return c.DoContext(ctx, "PUT", "GSLB/"+zone+"/"+fqdn+"/", gslb, nil)
+}
+
+// DeleteGSLB stages the deletion of the GSLB service of fqdn, along with its
+// records.
+func (c *Client) DeleteGSLB(ctx context.Context, zone, fqdn string) error {
+	return c.DoContext(ctx, "DELETE", "GSLB/"+zone+"/"+fqdn+"/", nil, nil)
+}
@@ -0,0 +1,103 @@
+package dyn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetGSLB(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/GSLB/example.com/www.example.com/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "fqdn": "www.example.com", "status": "ok",
+			"contact_nickname": "owner", "ttl": 30, "auto_recover": "Y", "notify_events": "ip,svc",
+			"monitor": {"protocol": "HTTP", "interval": 5, "port": "8080", "path": "/health"},
+			"region": [
+				{"region_code": "EU West", "serve_count": 1, "failover_mode": "global", "pool": [
+					{"address": "192.168.1.10", "weight": 1, "serve_mode": "obey", "status": "down"}
+				]},
+				{"region_code": "global", "serve_count": "2", "failover_mode": "global", "pool": [
+					{"address": "192.168.0.11", "weight": 1, "serve_mode": "obey", "status": "up"},
+					{"address": "192.168.0.10", "label": "primary", "weight": "10", "serve_mode": "obey", "status": "up"}
+				]}
+			]}}`)
+	}))
+	defer server.Close()
+
+	gslb, err := testClient(server).GetGSLB(context.Background(), "example.com", "www.example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if gslb.Status != "ok" || looseInt(gslb.TTL) != 30 || gslb.Monitor.Path != "/health" {
+		t.Fatalf("unexpected service: %#v", gslb)
+	}
+
+	configured := []interface{}{
+		map[string]interface{}{"region_code": "global", "pool": []interface{}{
+			map[string]interface{}{"address": "192.168.0.10"},
+			map[string]interface{}{"address": "192.168.0.11"},
+		}},
+		map[string]interface{}{"region_code": "EU West"},
+	}
+	regions := flattenGSLBRegions(gslb.Region, configured)
+	if regions[0]["region_code"] != "global" || regions[1]["region_code"] != "EU West" {
+		t.Fatalf("regions are not in the configured order: %#v", regions)
+	}
+	pool := regions[0]["pool"].([]map[string]interface{})
+	expected := map[string]interface{}{
+		"address": "192.168.0.10", "label": "primary", "weight": 10, "serve_mode": "obey", "status": "up",
+	}
+	if !reflect.DeepEqual(pool[0], expected) {
+		t.Fatalf("expected %#v, got %#v", expected, pool[0])
+	}
+	if pool[1]["address"] != "192.168.0.11" {
+		t.Fatalf("pool addresses are not in the configured order: %#v", pool)
+	}
+}
+
+func TestGSLB_clearedFields(t *testing.T) {
+	// fields left out of an update keep their value on Dyn's side
+	gslb := GSLB{
+		Monitor: ServiceMonitor{Protocol: "PING"},
+		Region:  []GSLBRegion{{RegionCode: "global", Pool: []GSLBAddress{{Address: "192.168.0.10"}}}},
+	}
+	body, err := json.Marshal(gslb)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, field := range []string{`"notify_events":""`, `"syslog_server":""`, `"failover_data":""`, `"label":""`, `"path":""`, `"expected":""`} {
+		if !strings.Contains(string(body), field) {
+			t.Errorf("expected %s in %s", field, body)
+		}
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	block := []interface{}{map[string]interface{}{
+		"protocol": "HTTPS", "interval": 1, "retries": 2, "timeout": 0, "port": 8443,
+		"path": "/health", "host": "www.example.com", "header": "", "expected": "OK",
+	}}
+
+	monitor := expandMonitor(block)
+	if monitor.Interval != "1" || monitor.Retries != "2" || monitor.Timeout != "" || monitor.Port != "8443" {
+		t.Fatalf("unexpected monitor: %#v", monitor)
+	}
+
+	flattened := flattenMonitor(monitor)
+	if !reflect.DeepEqual(flattened[0], block[0]) {
+		t.Fatalf("expected %#v, got %#v", block[0], flattened[0])
+	}
+
+	block[0].(map[string]interface{})["retries"] = 0
+	if monitor := expandMonitor(block); monitor.Retries != "0" {
+		t.Fatalf("expected 0 retries to be sent, got %q", monitor.Retries)
+	}
+}
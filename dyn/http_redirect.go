@@ -0,0 +1,55 @@
+package dyn
+
+import (
+	"context"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// HTTPRedirect is an HTTP Redirect service attached to a node.
+type HTTPRedirect struct {
+	Zone    string      `json:"zone,omitempty"`
+	FQDN    string      `json:"fqdn,omitempty"`
+	Code    looseString `json:"code"`
+	KeepURI string      `json:"keep_uri"`
+	URL     string      `json:"url"`
+}
+
+// httpRedirectRequest is the body of a request creating or updating an HTTP
+// Redirect service. The zone is published separately, with notes.
+type httpRedirectRequest struct {
+	Code    string `json:"code"`
+	KeepURI string `json:"keep_uri"`
+	URL     string `json:"url"`
+	Publish string `json:"publish"`
+}
+
+type httpRedirectResponse struct {
+	dynect.ResponseBlock
+	Data HTTPRedirect `json:"data"`
+}
+
+// GetHTTPRedirect reads the HTTP Redirect service of fqdn.
+func (c *Client) GetHTTPRedirect(ctx context.Context, zone, fqdn string) (*HTTPRedirect, error) {
+	var resp httpRedirectResponse
+	if err := c.DoContext(ctx, "GET", "HTTPRedirect/"+zone+"/"+fqdn+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateHTTPRedirect stages an HTTP Redirect service at fqdn.
+func (c *Client) CreateHTTPRedirect(ctx context.Context, zone, fqdn string, redirect *httpRedirectRequest) error {
+	return c.DoContext(ctx, "POST", "HTTPRedirect/"+zone+"/"+fqdn+"/", redirect, nil)
+}
+
+// UpdateHTTPRedirect stages changes to the HTTP Redirect service of fqdn.
+func (c *Client) UpdateHTTPRedirect(ctx context.Context, zone, fqdn string, redirect *httpRedirectRequest) error {
+	return c.DoContext(ctx, "PUT", "HTTPRedirect/"+zone+"/"+fqdn+"/", redirect, nil)
+}
+
+// DeleteHTTPRedirect stages the deletion of the HTTP Redirect service of
+// fqdn, along with its records.
+func (c *Client) DeleteHTTPRedirect(ctx context.Context, zone, fqdn string) error {
+	return c.DoContext(ctx, "DELETE", "HTTPRedirect/"+zone+"/"+fqdn+"/", nil, nil)
+}
@@ -11,7 +11,7 @@ import (
 func resourceDynRecordImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	results := make([]*schema.ResourceData, 1, 1)
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*Client)
 
 	values := strings.Split(d.Id(), "/")
 
@@ -42,12 +42,12 @@ func resourceDynRecordImportState(d *schema.ResourceData, meta interface{}) ([]*
 
 	// If we already have the record ID, use it for the lookup
 	if record.ID == "" {
-		err := client.GetRecordID(record)
+		err := client.GetRecordID(client.StopContext(), record)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		err := client.GetRecord(record)
+		err := client.GetRecord(client.StopContext(), record)
 		if err != nil {
 			return nil, err
 		}
@@ -56,10 +56,10 @@ func resourceDynRecordImportState(d *schema.ResourceData, meta interface{}) ([]*
 	d.SetId(record.ID)
 	d.Set("name", record.Name)
 	d.Set("zone", record.Zone)
-	d.Set("value", record.Value)
+	d.Set("value", normalizeRecordValue(record.Type, record.Value))
 	d.Set("type", record.Type)
 	d.Set("fqdn", record.FQDN)
-	d.Set("ttl", record.TTL)
+	d.Set("ttl", stateTTL(record.TTL))
 	results[0] = d
 
 	return results, nil
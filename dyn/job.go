@@ -0,0 +1,166 @@
+package dyn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// JobError is returned when a DynECT job fails, or when the client stops
+// waiting for a job that is still running on the server side.
+type JobError struct {
+	JobID    int
+	Status   string
+	Messages []dynect.MessageBlock
+	Err      error
+}
+
+func (e *JobError) Error() string {
+	if e.Pending() {
+		return fmt.Sprintf("gave up waiting for Dyn job %d, which is still running: %s", e.JobID, e.Err)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("Dyn job %d failed: %s", e.JobID, e.Err)
+	}
+	return fmt.Sprintf("Dyn job %d failed: %v", e.JobID, e.Messages)
+}
+
+// Pending reports whether the job may still complete on its own, so that
+// a later run can wait on it instead of submitting the change again.
+func (e *JobError) Pending() bool {
+	return e.Status == "incomplete" && e.JobID != 0
+}
+
+// pendingJobID returns the ID of the job err gave up on, or 0 if err is not
+// about a job that is still running.
+func pendingJobID(err error) int {
+	var jobErr *JobError
+	if errors.As(err, &jobErr) && jobErr.Pending() {
+		return jobErr.JobID
+	}
+	return 0
+}
+
+// GetJob fetches the current state of a job from Job/{id}. When the job has
+// completed successfully its result is decoded into responseData.
+func (c *Client) GetJob(ctx context.Context, id int, responseData interface{}) (*dynect.JobData, error) {
+	job, text, err := c.fetchJob(ctx, fmt.Sprintf("%s/Job/%d", c.baseURL, id))
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == "success" && responseData != nil {
+		if err := json.Unmarshal(text, responseData); err != nil {
+			return job, fmt.Errorf("failed to decode response body of Dyn job %d: %s", id, err)
+		}
+	}
+	return job, nil
+}
+
+// WaitJob polls Job/{id} until the job completes, MaxWait elapses or ctx is
+// done, whichever comes first.
+func (c *Client) WaitJob(ctx context.Context, id int, responseData interface{}) error {
+	return c.waitJob(ctx, id, fmt.Sprintf("%s/Job/%d", c.baseURL, id), responseData)
+}
+
+// waitJob polls the job at urlStr until it leaves the "incomplete" state.
+func (c *Client) waitJob(ctx context.Context, id int, urlStr string, responseData interface{}) error {
+	c.logger.Debug("waiting for job", "job_id", id)
+
+	if c.MaxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.MaxWait)
+		defer cancel()
+	}
+
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return &JobError{JobID: id, Status: "incomplete", Err: ctx.Err()}
+		case <-timer.C:
+		}
+
+		job, text, err := c.fetchJob(ctx, urlStr)
+		if err != nil {
+			if ctx.Err() != nil {
+				return &JobError{JobID: id, Status: "incomplete", Err: ctx.Err()}
+			}
+			return err
+		}
+		if job.ID != 0 {
+			id = job.ID
+		}
+
+		switch job.Status {
+		case "incomplete":
+			timer.Reset(interval)
+			continue
+		case "success":
+			c.logger.Debug("job completed", "job_id", id)
+			if responseData == nil {
+				return nil
+			}
+			if err := json.Unmarshal(text, responseData); err != nil {
+				return fmt.Errorf("failed to decode response body of Dyn job %d: %s", id, err)
+			}
+			return nil
+		case "failure":
+			return &JobError{JobID: id, Status: job.Status, Messages: job.Messages}
+		}
+		return &JobError{JobID: id, Status: job.Status, Err: fmt.Errorf("unexpected job status %q", job.Status)}
+	}
+}
+
+func (c *Client) fetchJob(ctx context.Context, urlStr string) (*dynect.JobData, []byte, error) {
+	resp, err := c.roundTrip(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	text, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not read response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("server responded with %v: %v", resp.Status, string(text))
+	}
+
+	var job dynect.JobData
+	if err := json.Unmarshal(text, &job); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode job response body: %s", err)
+	}
+	return &job, text, nil
+}
+
+// jobURL turns the Location of a job redirect into an absolute URL.
+func (c *Client) jobURL(loc string) string {
+	if strings.HasPrefix(loc, "http://") || strings.HasPrefix(loc, "https://") {
+		return loc
+	}
+	return fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(loc, "/REST/"))
+}
+
+// jobIDFromLocation extracts the job ID from a redirect to /REST/Job/{id}.
+func jobIDFromLocation(loc string) int {
+	id, err := strconv.Atoi(path.Base(strings.TrimSuffix(loc, "/")))
+	if err != nil {
+		return 0
+	}
+	return id
+}
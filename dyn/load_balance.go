@@ -0,0 +1,87 @@
+package dyn
+
+import (
+	"context"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// LoadBalance is a Load Balancing service attached to a node, which spreads
+// answers across the healthy addresses of its pool. Fields that can be
+// cleared are sent even when empty, as Dyn keeps the current value of a
+// field left out of an update.
+type LoadBalance struct {
+	Zone            string             `json:"zone,omitempty"`
+	FQDN            string             `json:"fqdn,omitempty"`
+	Status          string             `json:"status,omitempty"`
+	ServeCount      looseString        `json:"serve_count"`
+	FailoverMode    string             `json:"failover_mode"`
+	FailoverData    string             `json:"failover_data"`
+	ContactNickname string             `json:"contact_nickname"`
+	TTL             looseString        `json:"ttl"`
+	AutoRecover     string             `json:"auto_recover"`
+	NotifyEvents    string             `json:"notify_events"`
+	Monitor         ServiceMonitor     `json:"monitor"`
+	Pool            []LoadBalanceEntry `json:"pool,omitempty"`
+	Publish         string             `json:"publish,omitempty"`
+}
+
+// LoadBalanceEntry is an address of the pool of a Load Balancing service,
+// with the health Dyn last found it in.
+type LoadBalanceEntry struct {
+	Address   string      `json:"address"`
+	Label     string      `json:"label"`
+	Weight    looseString `json:"weight"`
+	ServeMode string      `json:"serve_mode"`
+	Status    string      `json:"status,omitempty"`
+	Publish   string      `json:"publish,omitempty"`
+}
+
+type loadBalanceResponse struct {
+	dynect.ResponseBlock
+	Data LoadBalance `json:"data"`
+}
+
+// GetLoadBalance reads the Load Balancing service of fqdn.
+func (c *Client) GetLoadBalance(ctx context.Context, zone, fqdn string) (*LoadBalance, error) {
+	var resp loadBalanceResponse
+	if err := c.DoContext(ctx, "GET", "LoadBalance/"+zone+"/"+fqdn+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateLoadBalance stages a Load Balancing service at fqdn.
+func (c *Client) CreateLoadBalance(ctx context.Context, zone, fqdn string, lb *LoadBalance) error {
+	return c.DoContext(ctx, "POST", "LoadBalance/"+zone+"/"+fqdn+"/", lb, nil)
+}
+
+// UpdateLoadBalance stages changes to the Load Balancing service of fqdn.
+// Its pool is replaced when lb has one, and left alone otherwise.
+func (c *Client) UpdateLoadBalance(ctx context.Context, zone, fqdn string, lb *LoadBalance) error {
+	return c.DoContext(ctx, "PUT", "LoadBalance/"+zone+"/"+fqdn+"/", lb, nil)
+}
+
+// DeleteLoadBalance stages the deletion of the Load Balancing service of
+// fqdn, along with its records.
+func (c *Client) DeleteLoadBalance(ctx context.Context, zone, fqdn string) error {
+	return c.DoContext(ctx, "DELETE", "LoadBalance/"+zone+"/"+fqdn+"/", nil, nil)
+}
+
+// CreateLoadBalanceEntry stages the addition of an address to the pool of the
+// Load Balancing service of fqdn.
+func (c *Client) CreateLoadBalanceEntry(ctx context.Context, zone, fqdn string, entry *LoadBalanceEntry) error {
+	return c.DoContext(ctx, "POST", "LoadBalancePoolEntry/"+zone+"/"+fqdn+"/", entry, nil)
+}
+
+// UpdateLoadBalanceEntry stages changes to the entry of address in the pool
+// of the Load Balancing service of fqdn.
+func (c *Client) UpdateLoadBalanceEntry(ctx context.Context, zone, fqdn, address string, entry *LoadBalanceEntry) error {
+	return c.DoContext(ctx, "PUT", "LoadBalancePoolEntry/"+zone+"/"+fqdn+"/"+address+"/", entry, nil)
+}
+
+// DeleteLoadBalanceEntry stages the removal of address from the pool of the
+// Load Balancing service of fqdn.
+func (c *Client) DeleteLoadBalanceEntry(ctx context.Context, zone, fqdn, address string) error {
+	return c.DoContext(ctx, "DELETE", "LoadBalancePoolEntry/"+zone+"/"+fqdn+"/"+address+"/", nil, nil)
+}
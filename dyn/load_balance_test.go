@@ -0,0 +1,97 @@
+package dyn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetLoadBalance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/LoadBalance/example.com/www.example.com/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "fqdn": "www.example.com", "status": "ok",
+			"serve_count": "2", "failover_mode": "global", "contact_nickname": "owner", "ttl": 30,
+			"auto_recover": "Y", "monitor": {"protocol": "HTTP", "interval": 5, "path": "/"},
+			"pool": [
+				{"address": "192.168.0.12", "weight": 1, "serve_mode": "obey", "status": "unk"},
+				{"address": "192.168.0.11", "label": "b", "weight": "5", "serve_mode": "remove", "status": "down"},
+				{"address": "192.168.0.10", "label": "a", "weight": 10, "serve_mode": "obey", "status": "up"}
+			]}}`)
+	}))
+	defer server.Close()
+
+	lb, err := testClient(server).GetLoadBalance(context.Background(), "example.com", "www.example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if lb.Status != "ok" || looseInt(lb.ServeCount) != 2 || len(lb.Pool) != 3 {
+		t.Fatalf("unexpected service: %#v", lb)
+	}
+
+	configured := []interface{}{
+		map[string]interface{}{"address": "192.168.0.10"},
+		map[string]interface{}{"address": "192.168.0.11"},
+	}
+	pool := flattenLoadBalancePool(lb.Pool, configured)
+	var addresses []string
+	for _, e := range pool {
+		addresses = append(addresses, e["address"].(string))
+	}
+	expected := []string{"192.168.0.10", "192.168.0.11", "192.168.0.12"}
+	if !reflect.DeepEqual(addresses, expected) {
+		t.Fatalf("expected the pool in order %v, got %v", expected, addresses)
+	}
+	if pool[1]["weight"] != 5 || pool[1]["status"] != "down" {
+		t.Fatalf("unexpected entry: %#v", pool[1])
+	}
+}
+
+func TestDiffLoadBalancePool(t *testing.T) {
+	old := []LoadBalanceEntry{
+		{Address: "192.168.0.10", Weight: "1", ServeMode: "obey"},
+		{Address: "192.168.0.11", Weight: "1", ServeMode: "obey"},
+		{Address: "192.168.0.12", Weight: "1", ServeMode: "obey"},
+	}
+	desired := []LoadBalanceEntry{
+		{Address: "192.168.0.12", Weight: "1", ServeMode: "obey"},
+		{Address: "192.168.0.10", Weight: "5", ServeMode: "obey"},
+		{Address: "192.168.0.13", Weight: "1", ServeMode: "always"},
+	}
+
+	added, changed, removed := diffLoadBalancePool(old, desired)
+	if len(added) != 1 || added[0].Address != "192.168.0.13" {
+		t.Fatalf("unexpected added entries: %#v", added)
+	}
+	if len(changed) != 1 || changed[0].Address != "192.168.0.10" || changed[0].Weight != "5" {
+		t.Fatalf("unexpected changed entries: %#v", changed)
+	}
+	if !reflect.DeepEqual(removed, []string{"192.168.0.11"}) {
+		t.Fatalf("unexpected removed addresses: %#v", removed)
+	}
+
+	added, changed, removed = diffLoadBalancePool(old, old)
+	if len(added)+len(changed)+len(removed) != 0 {
+		t.Fatalf("expected no changes, got %#v %#v %#v", added, changed, removed)
+	}
+}
+
+func TestLoadBalance_clearedFields(t *testing.T) {
+	// fields left out of an update keep their value on Dyn's side
+	body, err := json.Marshal(LoadBalance{Pool: []LoadBalanceEntry{{Address: "192.168.0.10"}}})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, field := range []string{`"notify_events":""`, `"failover_data":""`, `"label":""`} {
+		if !strings.Contains(string(body), field) {
+			t.Errorf("expected %s in %s", field, body)
+		}
+	}
+}
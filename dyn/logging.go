@@ -0,0 +1,102 @@
+package dyn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform/helper/logging"
+)
+
+// EnvHTTPTrace turns on HTTP tracing of the Dyn client independently of
+// TF_LOG. It accepts a log level name, or any other non-empty value for
+// "trace".
+const EnvHTTPTrace = "DYN_HTTP_TRACE"
+
+const redacted = "<redacted>"
+
+// sensitiveKeys are the JSON keys and HTTP headers whose values are never
+// written to the logs.
+var sensitiveKeys = map[string]bool{
+	"auth-token": true,
+	"password":   true,
+	"token":      true,
+}
+
+// newHTTPLogger returns the logger used for tracing requests to the DynECT
+// API. Summaries of each request are logged at DEBUG, and redacted headers
+// and bodies at TRACE.
+func newHTTPLogger() hclog.Logger {
+	level := hclog.LevelFromString(logging.LogLevel())
+	if v := os.Getenv(EnvHTTPTrace); v != "" {
+		level = hclog.LevelFromString(v)
+		if level == hclog.NoLevel {
+			level = hclog.Trace
+		}
+	}
+	if level == hclog.NoLevel || level > hclog.Debug {
+		return hclog.NewNullLogger()
+	}
+
+	return hclog.New(&hclog.LoggerOptions{
+		Name:   "dyn-http",
+		Level:  level,
+		Output: os.Stderr,
+	})
+}
+
+// redactHeaders renders HTTP headers with credentials masked.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if sensitiveKeys[strings.ToLower(k)] {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// redactBody renders a JSON body with credentials and session tokens
+// masked. Bodies that are not JSON are not rendered at all.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON data>", len(body))
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(redactValue(v)); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	return strings.TrimSpace(out.String())
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if sensitiveKeys[strings.ToLower(k)] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redactValue(val)
+		}
+	}
+	return v
+}
@@ -0,0 +1,95 @@
+package dyn
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected string
+	}{
+		{
+			`{"user_name": "terraform", "password": "hunter2", "customer_name": "hashicorp"}`,
+			`{"customer_name":"hashicorp","password":"<redacted>","user_name":"terraform"}`,
+		},
+		{
+			`{"status": "success", "data": {"token": "s3cr3t", "version": "3.7.0"}}`,
+			`{"data":{"token":"<redacted>","version":"3.7.0"},"status":"success"}`,
+		},
+		{
+			`{"data": [{"Auth-Token": "s3cr3t"}]}`,
+			`{"data":[{"Auth-Token":"<redacted>"}]}`,
+		},
+		{
+			`password=hunter2`,
+			`<16 bytes of non-JSON data>`,
+		},
+		{
+			``,
+			``,
+		},
+	}
+
+	for _, tc := range cases {
+		if actual := redactBody([]byte(tc.body)); actual != tc.expected {
+			t.Errorf("redactBody(%q): expected %q, got %q", tc.body, tc.expected, actual)
+		}
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Auth-Token", "s3cr3t")
+	h.Set("Content-Type", "application/json")
+
+	actual := redactHeaders(h)
+	if actual["Auth-Token"] != redacted {
+		t.Fatalf("expected Auth-Token to be redacted, got %q", actual["Auth-Token"])
+	}
+	if actual["Content-Type"] != "application/json" {
+		t.Fatalf("expected Content-Type to be kept, got %q", actual["Content-Type"])
+	}
+}
+
+func TestClientLogin_traceIsRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "success", "data": {"token": "s3cr3t-token", "version": "3.7.0"}}`)
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	client := NewClient("terraform")
+	client.baseURL = server.URL + "/REST"
+	client.SetLogger(hclog.New(&hclog.LoggerOptions{
+		Level:  hclog.Trace,
+		Output: &out,
+	}))
+
+	if err := client.Login(context.Background(), "terraform", "hunter2"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.Token != "s3cr3t-token" {
+		t.Fatalf("expected the session token to be set, got %q", client.Token)
+	}
+
+	logs := out.String()
+	for _, secret := range []string{"hunter2", "s3cr3t-token"} {
+		if strings.Contains(logs, secret) {
+			t.Fatalf("expected %q to be redacted from the logs:\n%s", secret, logs)
+		}
+	}
+	for _, expected := range []string{"method=POST", "endpoint=Session", "status=200", "duration="} {
+		if !strings.Contains(logs, expected) {
+			t.Fatalf("expected %q in the logs:\n%s", expected, logs)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package dyn
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// syslogFacilities are the facilities the traffic management services can
+// send health changes to a syslog server with.
+var syslogFacilities = []string{"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7"}
+
+// ServiceMonitor is the health check of the addresses of a traffic
+// management service such as GSLB or Active Failover.
+type ServiceMonitor struct {
+	Protocol string      `json:"protocol"`
+	Interval looseString `json:"interval"`
+	Retries  looseString `json:"retries"`
+	Timeout  looseString `json:"timeout,omitempty"`
+	Port     looseString `json:"port,omitempty"`
+	Path     string      `json:"path"`
+	Host     string      `json:"host"`
+	Header   string      `json:"header"`
+	Expected string      `json:"expected"`
+}
+
+// monitorSchema is the schema of the monitor block of the traffic management
+// services.
+func monitorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"protocol": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateStringInSlice([]string{"HTTP", "HTTPS", "PING", "SMTP", "TCP"}),
+				},
+				"interval": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					ValidateFunc: validateIntInSlice([]int{1, 5, 10, 15}),
+				},
+				"retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validateIntBetween(0, 2),
+				},
+				"timeout": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validateIntInSlice([]int{10, 15, 25, 30}),
+				},
+				"port": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validateIntBetween(1, 65535),
+				},
+				"path": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"host": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"header": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"expected": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// expandMonitor builds the monitor of a service from its monitor block.
+func expandMonitor(v interface{}) ServiceMonitor {
+	m := v.([]interface{})[0].(map[string]interface{})
+	monitor := ServiceMonitor{
+		Protocol: m["protocol"].(string),
+		Interval: looseString(strconv.Itoa(m["interval"].(int))),
+		Path:     m["path"].(string),
+		Host:     m["host"].(string),
+		Header:   m["header"].(string),
+		Expected: m["expected"].(string),
+		// 0 retries is a valid setting, unlike a timeout or port of 0, which
+		// leave the choice to Dyn
+		Retries: looseString(strconv.Itoa(m["retries"].(int))),
+	}
+	if timeout := m["timeout"].(int); timeout > 0 {
+		monitor.Timeout = looseString(strconv.Itoa(timeout))
+	}
+	if port := m["port"].(int); port > 0 {
+		monitor.Port = looseString(strconv.Itoa(port))
+	}
+	return monitor
+}
+
+// flattenMonitor renders the monitor of a service as a monitor block.
+func flattenMonitor(monitor ServiceMonitor) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"protocol": monitor.Protocol,
+		"interval": looseInt(monitor.Interval),
+		"retries":  looseInt(monitor.Retries),
+		"timeout":  looseInt(monitor.Timeout),
+		"port":     looseInt(monitor.Port),
+		"path":     monitor.Path,
+		"host":     monitor.Host,
+		"header":   monitor.Header,
+		"expected": monitor.Expected,
+	}}
+}
+
+// looseInt converts a number the Dyn API returned, as a string or not, to an
+// int, with 0 for a missing number.
+func looseInt(s looseString) int {
+	i, _ := strconv.Atoi(string(s))
+	return i
+}
@@ -0,0 +1,82 @@
+package dyn
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// normalizeRecordValue returns the canonical form of a record value, so that
+// equivalent ways of writing a value compare equal. It is used both when
+// diffing the configuration and when reading records back from Dyn. Values
+// that cannot be parsed for their type are returned unchanged.
+func normalizeRecordValue(recordType, value string) string {
+	value = strings.TrimSpace(value)
+
+	switch recordType {
+	case "A", "AAAA":
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.String()
+		}
+	case "ALIAS", "CNAME", "NS", "PTR", "SOA":
+		return normalizeHostname(value)
+	case "MX":
+		preference, exchange, err := parseMXValue(value)
+		if err == nil {
+			return fmt.Sprintf("%d %s", preference, normalizeHostname(exchange))
+		}
+	case "SRV":
+		priority, weight, port, target, err := parseSRVValue(value)
+		if err == nil {
+			return fmt.Sprintf("%d %d %d %s", priority, weight, port, normalizeHostname(target))
+		}
+	case "TXT", "SPF":
+		return normalizeTXT(value)
+	}
+
+	return value
+}
+
+// normalizeHostname folds a domain name to lower case and makes it fully
+// qualified with a trailing dot.
+func normalizeHostname(name string) string {
+	name = strings.ToLower(name)
+	if name != "" && !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+// normalizeTXT strips the quotes around a TXT value made of a single string,
+// and separates the strings of a split value with single spaces.
+func normalizeTXT(value string) string {
+	if !strings.HasPrefix(value, `"`) {
+		return value
+	}
+
+	strs := txtStringRe.FindAllString(value, -1)
+	if strings.TrimSpace(txtStringRe.ReplaceAllString(value, "")) != "" {
+		return value
+	}
+	if len(strs) == 1 {
+		return strings.Trim(strs[0], `"`)
+	}
+	return strings.Join(strs, " ")
+}
+
+// normalizeRecordName folds a record name to lower case, and treats a name
+// equal to the zone as the zone apex.
+func normalizeRecordName(name, zone string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == strings.ToLower(strings.TrimSuffix(zone, ".")) {
+		return ""
+	}
+	return name
+}
+
+// relativeRecordName returns the name of a record relative to its zone,
+// folded to lower case, with the zone apex as "".
+func relativeRecordName(fqdn, zone string) string {
+	name := normalizeRecordName(fqdn, zone)
+	return strings.TrimSuffix(name, "."+strings.ToLower(strings.TrimSuffix(zone, ".")))
+}
@@ -0,0 +1,75 @@
+package dyn
+
+import "testing"
+
+func TestNormalizeRecordValue(t *testing.T) {
+	cases := []struct {
+		recordType string
+		value      string
+		expected   string
+	}{
+		{"A", "192.168.0.10", "192.168.0.10"},
+		{"A", " 192.168.0.10 ", "192.168.0.10"},
+		{"AAAA", "2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1"},
+		{"AAAA", "2001:DB8::1", "2001:db8::1"},
+		{"CNAME", "Something.Terraform.IO", "something.terraform.io."},
+		{"CNAME", "something.terraform.io.", "something.terraform.io."},
+		{"NS", "ns.terraform.io", "ns.terraform.io."},
+		{"ALIAS", "lb.terraform.io", "lb.terraform.io."},
+		{"PTR", "Host.terraform.io", "host.terraform.io."},
+		{"MX", "10 MX.terraform.io", "10 mx.terraform.io."},
+		{"MX", "10   mx.terraform.io.", "10 mx.terraform.io."},
+		{"SRV", "10 5 5060 SIP.terraform.io", "10 5 5060 sip.terraform.io."},
+		{"TXT", `"v=spf1 -all"`, "v=spf1 -all"},
+		{"TXT", "v=spf1 -all", "v=spf1 -all"},
+		{"TXT", `"part1"   "part2"`, `"part1" "part2"`},
+		{"TXT", `"part1" part2`, `"part1" part2`},
+		{"MX", "not an mx", "not an mx"},
+	}
+
+	for _, tc := range cases {
+		if actual := normalizeRecordValue(tc.recordType, tc.value); actual != tc.expected {
+			t.Errorf("normalizeRecordValue(%q, %q): expected %q, got %q", tc.recordType, tc.value, tc.expected, actual)
+		}
+	}
+}
+
+func TestNormalizeRecordName(t *testing.T) {
+	cases := []struct {
+		name     string
+		zone     string
+		expected string
+	}{
+		{"", "terraform.io", ""},
+		{"terraform.io", "terraform.io", ""},
+		{"Terraform.IO.", "terraform.io", ""},
+		{"WWW", "terraform.io", "www"},
+		{"www", "terraform.io", "www"},
+	}
+
+	for _, tc := range cases {
+		if actual := normalizeRecordName(tc.name, tc.zone); actual != tc.expected {
+			t.Errorf("normalizeRecordName(%q, %q): expected %q, got %q", tc.name, tc.zone, tc.expected, actual)
+		}
+	}
+}
+
+func TestRelativeRecordName(t *testing.T) {
+	cases := []struct {
+		fqdn     string
+		expected string
+	}{
+		{"example.com", ""},
+		{"example.com.", ""},
+		{"www.example.com", "www"},
+		{"WWW.Example.com.", "www"},
+		{"_sip._tcp.example.com", "_sip._tcp"},
+		{"www.example.org", "www.example.org"},
+	}
+
+	for _, tc := range cases {
+		if actual := relativeRecordName(tc.fqdn, "example.com"); actual != tc.expected {
+			t.Errorf("relativeRecordName(%q): expected %q, got %q", tc.fqdn, tc.expected, actual)
+		}
+	}
+}
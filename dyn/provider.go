@@ -1,13 +1,15 @@
 package dyn
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"customer_name": {
 				Type:        schema.TypeString,
@@ -29,21 +31,108 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("DYN_PASSWORD", nil),
 				Description: "The Dyn password.",
 			},
+
+			"job_poll_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DYN_JOB_POLL_INTERVAL", "1s"),
+				ValidateFunc: validateDuration,
+				Description:  "How often to poll a Dyn job that is still running.",
+			},
+
+			"job_max_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DYN_JOB_MAX_WAIT", "10m"),
+				ValidateFunc: validateDuration,
+				Description:  "How long to wait for a single Dyn job before giving up.",
+			},
+
+			"foreign_changes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DYN_FOREIGN_CHANGES", ForeignChangesWarn),
+				ValidateFunc: validateStringInSlice([]string{ForeignChangesFail, ForeignChangesWarn, ForeignChangesProceed}),
+				Description:  "What to do when a zone has unpublished changes that were not made by Terraform: fail, warn or proceed.",
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DYN_PUBLISH_NOTES", DefaultPublishNotes),
+				ValidateFunc: validatePublishNotes,
+				Description:  "The template of the note attached to each zone publish. An empty template sends no note.",
+			},
+
+			"auto_publish": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DYN_AUTO_PUBLISH", true),
+				Description: "Whether changes are published as soon as they are made. When false they are left pending, to be published by dyn_zone_publish.",
+			},
+
+			"manual_publish_zones": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Zones whose changes are left pending, to be published by dyn_zone_publish, whatever auto_publish is.",
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"dyn_dnssec_keys": dataSourceDynDNSSECKeys(),
+			"dyn_zone_export": dataSourceDynZoneExport(),
+			"dyn_zone_notes":  dataSourceDynZoneNotes(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"dyn_record": resourceDynRecord(),
+			"dyn_active_failover":   resourceDynActiveFailover(),
+			"dyn_advanced_redirect": resourceDynAdvancedRedirect(),
+			"dyn_ddns":              resourceDynDDNS(),
+			"dyn_ddns_host":         resourceDynDDNSHost(),
+			"dyn_dnssec":            resourceDynDNSSEC(),
+			"dyn_gslb":              resourceDynGSLB(),
+			"dyn_http_redirect":     resourceDynHTTPRedirect(),
+			"dyn_load_balance":      resourceDynLoadBalance(),
+			"dyn_record":            resourceDynRecord(),
+			"dyn_reverse_dns":       resourceDynReverseDNS(),
+			"dyn_rttm":              resourceDynRTTM(),
+			"dyn_zone_file":         resourceDynZoneFile(),
+			"dyn_zone_freeze":       resourceDynZoneFreeze(),
+			"dyn_zone_publish":      resourceDynZonePublish(),
+			"dyn_zone_records":      resourceDynZoneRecords(),
 		},
+	}
 
-		ConfigureFunc: providerConfigure,
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, provider)
 	}
+
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, p *schema.Provider) (interface{}, error) {
+	// Both durations have already been checked by validateDuration.
+	pollInterval, _ := time.ParseDuration(d.Get("job_poll_interval").(string))
+	maxWait, _ := time.ParseDuration(d.Get("job_max_wait").(string))
+
+	var manualPublishZones []string
+	for _, zone := range d.Get("manual_publish_zones").(*schema.Set).List() {
+		manualPublishZones = append(manualPublishZones, zone.(string))
+	}
+
 	config := Config{
-		CustomerName: d.Get("customer_name").(string),
-		Username:     d.Get("username").(string),
-		Password:     d.Get("password").(string),
+		CustomerName:       d.Get("customer_name").(string),
+		Username:           d.Get("username").(string),
+		Password:           d.Get("password").(string),
+		PollInterval:       pollInterval,
+		MaxWait:            maxWait,
+		ForeignChanges:     d.Get("foreign_changes").(string),
+		PublishNotes:       d.Get("publish_notes").(string),
+		AutoPublish:        d.Get("auto_publish").(bool),
+		ManualPublishZones: manualPublishZones,
+		StopContext:        p.StopContext(),
 	}
 
 	return config.Client()
@@ -45,3 +45,13 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("DYN_ZONE must be set for acceptance tests. The domain is used to ` and destroy record against.")
 	}
 }
+
+// testAccContactNickname returns the Dyn contact that acceptance tests of
+// services with notifications use, skipping the test when it is not set.
+func testAccContactNickname(t *testing.T) string {
+	contact := os.Getenv("DYN_CONTACT_NICKNAME")
+	if contact == "" {
+		t.Skip("DYN_CONTACT_NICKNAME must be set to the nickname of a Dyn contact for this acceptance test")
+	}
+	return contact
+}
@@ -0,0 +1,85 @@
+package dyn
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/user"
+	"text/template"
+)
+
+// DefaultPublishNotes is the template of the note attached to the publishes
+// made by the provider when publish_notes is not set. It leaves out the
+// workspace, which the provider can only tell when TF_WORKSPACE is set.
+const DefaultPublishNotes = "Terraform: {{.Resource}} (user {{.User}})"
+
+// publishNotesData is what a publish_notes template is rendered with.
+type publishNotesData struct {
+	// Resource identifies the resource making the change, such as
+	// "dyn_record A www.example.com".
+	Resource string
+
+	// Zone is the zone being published.
+	Zone string
+
+	// Workspace is the Terraform workspace, taken from TF_WORKSPACE as
+	// Terraform does not pass it on to providers.
+	Workspace string
+
+	// User is the local user running Terraform, and DynUser the Dyn user the
+	// provider is logged in as.
+	User    string
+	DynUser string
+}
+
+// renderPublishNotes renders the note attached to a publish of zone made for
+// resource. The template set on the resource, if any, takes precedence over
+// the one of the provider.
+func renderPublishNotes(client *Client, override, resource, zone string) (string, error) {
+	text := client.PublishNotes
+	if override != "" {
+		text = override
+	}
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("publish_notes").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse publish_notes: %s", err)
+	}
+
+	data := publishNotesData{
+		Resource:  resource,
+		Zone:      zone,
+		Workspace: terraformWorkspace(),
+		User:      localUser(),
+		DynUser:   client.username,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("Failed to render publish_notes: %s", err)
+	}
+	return buf.String(), nil
+}
+
+// terraformWorkspace returns the selected Terraform workspace as far as the
+// provider can tell.
+func terraformWorkspace() string {
+	if ws := os.Getenv("TF_WORKSPACE"); ws != "" {
+		return ws
+	}
+	return "default"
+}
+
+// localUser returns the name of the user running Terraform.
+func localUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,51 @@
+package dyn
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRenderPublishNotes(t *testing.T) {
+	os.Setenv("TF_WORKSPACE", "staging")
+	defer os.Unsetenv("TF_WORKSPACE")
+
+	client := NewClient("terraform")
+	client.username = "ops"
+
+	cases := []struct {
+		template string
+		override string
+		expected string
+	}{
+		{"", "", ""},
+		{"{{.Resource}} in {{.Zone}} ({{.Workspace}}, {{.DynUser}})", "", "dyn_record A www.example.com in example.com (staging, ops)"},
+		{"{{.Resource}}", "CHG-1234", "CHG-1234"},
+		{"", "CHG-1234: {{.Resource}}", "CHG-1234: dyn_record A www.example.com"},
+	}
+
+	for _, tc := range cases {
+		client.PublishNotes = tc.template
+		actual, err := renderPublishNotes(client, tc.override, "dyn_record A www.example.com", "example.com")
+		if err != nil {
+			t.Fatalf("%q, %q: err: %s", tc.template, tc.override, err)
+		}
+		if actual != tc.expected {
+			t.Errorf("%q, %q: expected %q, got %q", tc.template, tc.override, tc.expected, actual)
+		}
+	}
+}
+
+func TestRenderPublishNotes_defaultTemplate(t *testing.T) {
+	client := NewClient("terraform")
+	client.PublishNotes = DefaultPublishNotes
+
+	actual, err := renderPublishNotes(client, "", "dyn_record A www.example.com", "example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := "Terraform: dyn_record A www.example.com (user " + localUser() + ")"
+	if actual != expected {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+}
@@ -0,0 +1,281 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynActiveFailover() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynActiveFailoverCreate,
+		Read:   resourceDynActiveFailoverRead,
+		Update: resourceDynActiveFailoverUpdate,
+		Delete: resourceDynActiveFailoverDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynServiceImportState,
+		},
+
+		CustomizeDiff: resourceDynActiveFailoverCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRecordName,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"failover_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ip",
+				ValidateFunc: validateStringInSlice([]string{"ip", "cname"}),
+			},
+
+			"failover_data": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"monitor": monitorSchema(),
+
+			"contact_nickname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validateIntBetween(1, maxTTL),
+			},
+
+			"auto_recover": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"notify_events": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateStringInSlice([]string{"ip", "svc", "nosrv"}),
+				},
+				Set: schema.HashString,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"publish": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+		},
+	}
+}
+
+// resourceDynActiveFailoverCustomizeDiff checks that the primary address is
+// an IPv4 address, and the failover data an address or a hostname depending
+// on failover_mode.
+func resourceDynActiveFailoverCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("address") {
+		if err := checkRecordValue("A", d.Get("address").(string)); err != nil {
+			return fmt.Errorf("invalid address for an Active Failover service: %s", err)
+		}
+	}
+	if !d.NewValueKnown("failover_mode") || !d.NewValueKnown("failover_data") {
+		return nil
+	}
+	recordType := "A"
+	if d.Get("failover_mode").(string) == "cname" {
+		recordType = "CNAME"
+	}
+	if err := checkRecordValue(recordType, d.Get("failover_data").(string)); err != nil {
+		return fmt.Errorf("invalid failover_data for failover_mode %q: %s", d.Get("failover_mode").(string), err)
+	}
+	return nil
+}
+
+// resourceDynActiveFailoverRequest builds the service from the configuration.
+func resourceDynActiveFailoverRequest(d *schema.ResourceData) *ActiveFailover {
+	return &ActiveFailover{
+		Address:         d.Get("address").(string),
+		FailoverMode:    d.Get("failover_mode").(string),
+		FailoverData:    d.Get("failover_data").(string),
+		ContactNickname: d.Get("contact_nickname").(string),
+		TTL:             looseString(strconv.Itoa(d.Get("ttl").(int))),
+		AutoRecover:     yesNo(d.Get("auto_recover").(bool)),
+		NotifyEvents:    joinStringSet(d.Get("notify_events").(*schema.Set)),
+		Monitor:         expandMonitor(d.Get("monitor")),
+		Publish:         "N",
+	}
+}
+
+// resourceDynActiveFailoverNotes renders the note attached to the publishes
+// of the service at fqdn.
+func resourceDynActiveFailoverNotes(d *schema.ResourceData, client *Client, zone, fqdn string) (string, error) {
+	return renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_active_failover "+fqdn, zone)
+}
+
+func resourceDynActiveFailoverCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	fqdn := recordFQDN(d.Get("name").(string), zone)
+	notes, err := resourceDynActiveFailoverNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating Dyn Active Failover service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.CreateActiveFailover(ctx, zone, fqdn, resourceDynActiveFailoverRequest(d)); err != nil {
+			return fmt.Errorf("Failed to create Dyn Active Failover service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(fqdn)
+
+	return resourceDynActiveFailoverReadContext(ctx, d, client)
+}
+
+func resourceDynActiveFailoverRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynActiveFailoverReadContext(ctx, d, client)
+}
+
+// resourceDynActiveFailoverReadContext reads the service, including whether
+// it is currently serving the failover data.
+func resourceDynActiveFailoverReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	failover, err := client.GetActiveFailover(ctx, zone, fqdn)
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn Active Failover service %s was deleted outside of Terraform", fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn Active Failover service %s: %s", fqdn, err)
+	}
+
+	d.Set("fqdn", fqdn)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("address", failover.Address)
+	d.Set("failover_mode", failover.FailoverMode)
+	d.Set("failover_data", failover.FailoverData)
+	d.Set("contact_nickname", failover.ContactNickname)
+	d.Set("ttl", looseInt(failover.TTL))
+	d.Set("auto_recover", failover.AutoRecover != "N")
+	d.Set("notify_events", splitStringSet(failover.NotifyEvents))
+	d.Set("status", failover.Status)
+	return d.Set("monitor", flattenMonitor(failover.Monitor))
+}
+
+func resourceDynActiveFailoverUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	if !d.HasChange("address") && !d.HasChange("failover_mode") && !d.HasChange("failover_data") &&
+		!d.HasChange("monitor") && !d.HasChange("contact_nickname") && !d.HasChange("ttl") &&
+		!d.HasChange("auto_recover") && !d.HasChange("notify_events") {
+		// publish and publish_notes only apply to later changes
+		return resourceDynActiveFailoverReadContext(ctx, d, client)
+	}
+
+	notes, err := resourceDynActiveFailoverNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating Dyn Active Failover service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.UpdateActiveFailover(ctx, zone, fqdn, resourceDynActiveFailoverRequest(d)); err != nil {
+			return fmt.Errorf("Failed to update Dyn Active Failover service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceDynActiveFailoverReadContext(ctx, d, client)
+}
+
+func resourceDynActiveFailoverDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	notes, err := resourceDynActiveFailoverNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Dyn Active Failover service %s", fqdn)
+	return stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.DeleteActiveFailover(ctx, zone, fqdn); err != nil {
+			return fmt.Errorf("Failed to delete Dyn Active Failover service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynActiveFailover_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+	contact := testAccContactNickname(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynActiveFailoverDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynActiveFailoverConfig, zone, contact, "192.168.0.11"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_active_failover.foobar", "fqdn", "terraform-failover."+zone),
+					resource.TestCheckResourceAttr("dyn_active_failover.foobar", "failover_data", "192.168.0.11"),
+					resource.TestCheckResourceAttrSet("dyn_active_failover.foobar", "status"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynActiveFailoverConfig, zone, contact, "192.168.0.12"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_active_failover.foobar", "failover_data", "192.168.0.12"),
+				),
+			},
+			{
+				ResourceName:            "dyn_active_failover.foobar",
+				ImportState:             true,
+				ImportStateId:           zone + "/terraform-failover." + zone,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"publish"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynActiveFailoverDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_active_failover" {
+			continue
+		}
+
+		_, err := client.GetActiveFailover(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Active Failover service still exists")
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynActiveFailoverConfig = `
+resource "dyn_active_failover" "foobar" {
+	zone = "%s"
+	name = "terraform-failover"
+	contact_nickname = "%s"
+	address = "192.168.0.10"
+	failover_data = "%s"
+
+	monitor {
+		protocol = "HTTP"
+		path = "/"
+	}
+}`
@@ -0,0 +1,270 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynAdvancedRedirect() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynAdvancedRedirectCreate,
+		Read:   resourceDynAdvancedRedirectRead,
+		Update: resourceDynAdvancedRedirectUpdate,
+		Delete: resourceDynAdvancedRedirectDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynServiceImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRecordName,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"url_pattern": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"code": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      301,
+							ValidateFunc: validateIntInSlice([]int{301, 302}),
+						},
+						"active": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"public_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+		},
+	}
+}
+
+// resourceDynAdvancedRedirectRequest builds the service from the
+// configuration, with its rules in order.
+func resourceDynAdvancedRedirectRequest(d *schema.ResourceData) *advancedRedirectRequest {
+	redirect := &advancedRedirectRequest{
+		Active:  yesNo(d.Get("active").(bool)),
+		Publish: "N",
+	}
+	for _, v := range d.Get("rule").([]interface{}) {
+		m := v.(map[string]interface{})
+		redirect.Rules = append(redirect.Rules, AdvancedRedirectRule{
+			HostPrefix: m["host_prefix"].(string),
+			Path:       m["path"].(string),
+			URLPattern: m["url_pattern"].(string),
+			Code:       looseString(strconv.Itoa(m["code"].(int))),
+			Active:     yesNo(m["active"].(bool)),
+		})
+	}
+	return redirect
+}
+
+// resourceDynAdvancedRedirectNotes renders the note attached to the
+// publishes of the service at fqdn.
+func resourceDynAdvancedRedirectNotes(d *schema.ResourceData, client *Client, zone, fqdn string) (string, error) {
+	return renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_advanced_redirect "+fqdn, zone)
+}
+
+func resourceDynAdvancedRedirectCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	fqdn := recordFQDN(d.Get("name").(string), zone)
+	notes, err := resourceDynAdvancedRedirectNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating Dyn advanced redirect %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.CreateAdvancedRedirect(ctx, zone, fqdn, resourceDynAdvancedRedirectRequest(d)); err != nil {
+			return fmt.Errorf("Failed to create Dyn advanced redirect %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(fqdn)
+
+	return resourceDynAdvancedRedirectReadContext(ctx, d, client)
+}
+
+func resourceDynAdvancedRedirectRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynAdvancedRedirectReadContext(ctx, d, client)
+}
+
+func resourceDynAdvancedRedirectReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	redirect, err := client.GetAdvancedRedirect(ctx, zone, fqdn)
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn advanced redirect %s was deleted outside of Terraform", fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn advanced redirect %s: %s", fqdn, err)
+	}
+
+	rules, err := flattenAdvancedRedirectRules(redirect.Rules)
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn advanced redirect %s: %s", fqdn, err)
+	}
+
+	d.Set("fqdn", fqdn)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("active", redirect.Active != "N")
+	return d.Set("rule", rules)
+}
+
+// flattenAdvancedRedirectRules lists the rules of a service in the order
+// they are evaluated.
+func flattenAdvancedRedirectRules(rules []AdvancedRedirectRule) ([]map[string]interface{}, error) {
+	flattened := make([]map[string]interface{}, len(rules))
+	for i, r := range rules {
+		code, err := strconv.Atoi(string(r.Code))
+		if err != nil {
+			return nil, fmt.Errorf("rule %s has an invalid code %q", r.PublicID, r.Code)
+		}
+		flattened[i] = map[string]interface{}{
+			"host_prefix": r.HostPrefix,
+			"path":        r.Path,
+			"url_pattern": r.URLPattern,
+			"code":        code,
+			"active":      r.Active != "N",
+			"public_id":   r.PublicID,
+		}
+	}
+	return flattened, nil
+}
+
+// resourceDynAdvancedRedirectUpdate replaces the rules of the service,
+// which reorders them without recreating it.
+func resourceDynAdvancedRedirectUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	if !d.HasChange("rule") && !d.HasChange("active") {
+		// publish and publish_notes only apply to later changes
+		return resourceDynAdvancedRedirectReadContext(ctx, d, client)
+	}
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	notes, err := resourceDynAdvancedRedirectNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating Dyn advanced redirect %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.UpdateAdvancedRedirect(ctx, zone, fqdn, resourceDynAdvancedRedirectRequest(d)); err != nil {
+			return fmt.Errorf("Failed to update Dyn advanced redirect %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceDynAdvancedRedirectReadContext(ctx, d, client)
+}
+
+func resourceDynAdvancedRedirectDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	notes, err := resourceDynAdvancedRedirectNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Dyn advanced redirect %s", fqdn)
+	return stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.DeleteAdvancedRedirect(ctx, zone, fqdn); err != nil {
+			return fmt.Errorf("Failed to delete Dyn advanced redirect %s: %s", fqdn, err)
+		}
+		return nil
+	})
+}
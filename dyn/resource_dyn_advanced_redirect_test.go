@@ -0,0 +1,88 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynAdvancedRedirect_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynAdvancedRedirectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynAdvancedRedirectConfig, zone, testAccDynAdvancedRedirectRuleOld, testAccDynAdvancedRedirectRuleShop),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_advanced_redirect.foobar", "fqdn", "terraform-adv-redirect."+zone),
+					resource.TestCheckResourceAttr("dyn_advanced_redirect.foobar", "rule.#", "2"),
+					resource.TestCheckResourceAttr("dyn_advanced_redirect.foobar", "rule.0.path", "/old/*"),
+					resource.TestCheckResourceAttr("dyn_advanced_redirect.foobar", "rule.1.host_prefix", "shop"),
+				),
+			},
+			{
+				// reordering the rules updates the service in place
+				Config: fmt.Sprintf(testAccCheckDynAdvancedRedirectConfig, zone, testAccDynAdvancedRedirectRuleShop, testAccDynAdvancedRedirectRuleOld),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_advanced_redirect.foobar", "rule.0.host_prefix", "shop"),
+					resource.TestCheckResourceAttr("dyn_advanced_redirect.foobar", "rule.1.path", "/old/*"),
+				),
+			},
+			{
+				ResourceName:            "dyn_advanced_redirect.foobar",
+				ImportState:             true,
+				ImportStateId:           zone + "/terraform-adv-redirect." + zone,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"publish"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynAdvancedRedirectDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_advanced_redirect" {
+			continue
+		}
+
+		_, err := client.GetAdvancedRedirect(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Advanced redirect still exists")
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccDynAdvancedRedirectRuleOld = `
+	rule {
+		path = "/old/*"
+		url_pattern = "https://www.example.org/new/"
+	}`
+
+const testAccDynAdvancedRedirectRuleShop = `
+	rule {
+		host_prefix = "shop"
+		url_pattern = "https://shop.example.org/"
+		code = 302
+	}`
+
+const testAccCheckDynAdvancedRedirectConfig = `
+resource "dyn_advanced_redirect" "foobar" {
+	zone = "%s"
+	name = "terraform-adv-redirect"
+%s
+%s
+}`
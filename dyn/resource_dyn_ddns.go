@@ -0,0 +1,284 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynDDNS() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynDDNSCreate,
+		Read:   resourceDynDDNSRead,
+		Update: resourceDynDDNSUpdate,
+		Delete: resourceDynDDNSDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynDDNSImportState,
+		},
+
+		CustomizeDiff: resourceDynDDNSCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRecordName,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"record_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "A",
+				ForceNew:     true,
+				ValidateFunc: validateStringInSlice([]string{"A", "AAAA"}),
+			},
+
+			"address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"current_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"abuse_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"last_updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"publish": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+		},
+	}
+}
+
+func resourceDynDDNSCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("address") || !d.NewValueKnown("record_type") {
+		return nil
+	}
+	recordType, address := d.Get("record_type").(string), d.Get("address").(string)
+	if err := checkRecordValue(recordType, address); err != nil {
+		return fmt.Errorf("invalid address for a Dynamic DNS %s record: %s", recordType, err)
+	}
+	return nil
+}
+
+// resourceDynDDNSNotes renders the note attached to the publishes of the
+// service at fqdn.
+func resourceDynDDNSNotes(d *schema.ResourceData, client *Client, zone, fqdn string) (string, error) {
+	resource := fmt.Sprintf("dyn_ddns %s %s", d.Get("record_type").(string), fqdn)
+	return renderPublishNotes(client, d.Get("publish_notes").(string), resource, zone)
+}
+
+func resourceDynDDNSCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone, recordType := d.Get("zone").(string), d.Get("record_type").(string)
+	fqdn := recordFQDN(d.Get("name").(string), zone)
+	notes, err := resourceDynDDNSNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	ddns := &ddnsRequest{
+		Address: d.Get("address").(string),
+		Active:  yesNo(d.Get("active").(bool)),
+		Publish: "N",
+	}
+	log.Printf("[INFO] Creating Dyn Dynamic DNS service for %s %s", recordType, fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.CreateDDNS(ctx, zone, fqdn, recordType, ddns); err != nil {
+			return fmt.Errorf("Failed to create Dyn Dynamic DNS service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(fqdn + "/" + recordType)
+
+	return resourceDynDDNSReadContext(ctx, d, client)
+}
+
+func resourceDynDDNSRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynDDNSReadContext(ctx, d, client)
+}
+
+// resourceDynDDNSReadContext reads the service. The address the hosts set is
+// recorded as current_address, so that their updates do not show up as
+// drift of address.
+func resourceDynDDNSReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone := d.Get("zone").(string)
+	fqdn, recordType := resourceDynDDNSParseID(d)
+	ddns, err := client.GetDDNS(ctx, zone, fqdn, recordType)
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn Dynamic DNS service %s %s was deleted outside of Terraform", recordType, fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn Dynamic DNS service %s: %s", fqdn, err)
+	}
+
+	abuseCount, _ := strconv.Atoi(string(ddns.AbuseCount))
+
+	d.SetId(fqdn + "/" + recordType)
+	d.Set("fqdn", fqdn)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("record_type", recordType)
+	d.Set("active", ddns.Active != "N")
+	d.Set("current_address", ddns.Address)
+	d.Set("abuse_count", abuseCount)
+	d.Set("last_updated", zoneNoteTimestamp(string(ddns.LastUpdated)))
+	if d.Get("address").(string) == "" {
+		// imported services start from the current address
+		d.Set("address", ddns.Address)
+	}
+	return nil
+}
+
+func resourceDynDDNSUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	if !d.HasChange("address") && !d.HasChange("active") {
+		// publish and publish_notes only apply to later changes
+		return resourceDynDDNSReadContext(ctx, d, client)
+	}
+
+	zone := d.Get("zone").(string)
+	fqdn, recordType := resourceDynDDNSParseID(d)
+	notes, err := resourceDynDDNSNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	ddns := &ddnsRequest{
+		Active:  yesNo(d.Get("active").(bool)),
+		Publish: "N",
+	}
+	if d.HasChange("address") {
+		// overrides the address last set by a host
+		ddns.Address = d.Get("address").(string)
+	}
+	log.Printf("[INFO] Updating Dyn Dynamic DNS service for %s %s", recordType, fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.UpdateDDNS(ctx, zone, fqdn, recordType, ddns); err != nil {
+			return fmt.Errorf("Failed to update Dyn Dynamic DNS service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceDynDDNSReadContext(ctx, d, client)
+}
+
+func resourceDynDDNSDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	fqdn, recordType := resourceDynDDNSParseID(d)
+	notes, err := resourceDynDDNSNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Dyn Dynamic DNS service for %s %s", recordType, fqdn)
+	return stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.DeleteDDNS(ctx, zone, fqdn, recordType); err != nil {
+			return fmt.Errorf("Failed to delete Dyn Dynamic DNS service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+}
+
+// resourceDynDDNSImportState imports a service by "zone/fqdn/record_type".
+func resourceDynDDNSImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	values := strings.Split(d.Id(), "/")
+	if len(values) != 3 {
+		return nil, fmt.Errorf("invalid id provided, expected format: {zone}/{fqdn}/{record_type}")
+	}
+
+	zone, fqdn, recordType := values[0], strings.TrimSuffix(values[1], "."), strings.ToUpper(values[2])
+	d.SetId(fqdn + "/" + recordType)
+	d.Set("zone", zone)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("fqdn", fqdn)
+	d.Set("record_type", recordType)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceDynDDNSParseID returns the FQDN and record type of the service from
+// its "fqdn/record_type" ID. IDs made before the record type was part of them
+// are only the FQDN, and take the record type from state.
+func resourceDynDDNSParseID(d *schema.ResourceData) (string, string) {
+	if i := strings.LastIndex(d.Id(), "/"); i >= 0 {
+		return d.Id()[:i], d.Id()[i+1:]
+	}
+	return d.Id(), d.Get("record_type").(string)
+}
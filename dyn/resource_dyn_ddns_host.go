@@ -0,0 +1,117 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynDDNSHost() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynDDNSHostCreate,
+		Read:   resourceDynDDNSHostRead,
+		Delete: resourceDynDDNSHostDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"record_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "A",
+				ForceNew:     true,
+				ValidateFunc: validateStringInSlice([]string{"A", "AAAA"}),
+			},
+
+			"user_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceDynDDNSHostCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone, fqdn, recordType := d.Get("zone").(string), d.Get("fqdn").(string), d.Get("record_type").(string)
+	log.Printf("[INFO] Creating a Dyn Dynamic DNS user for %s %s", recordType, fqdn)
+	host, err := client.CreateDDNSHost(ctx, zone, fqdn, recordType)
+	if err != nil {
+		return fmt.Errorf("Failed to create a Dyn Dynamic DNS user for %s: %s", fqdn, err)
+	}
+	if host.UserName == "" {
+		return fmt.Errorf("Dyn did not return the Dynamic DNS user it created for %s", fqdn)
+	}
+
+	d.SetId(host.UserName)
+	d.Set("user_name", host.UserName)
+	// the password is only returned now, and kept in state from then on
+	d.Set("password", host.Password)
+	return nil
+}
+
+func resourceDynDDNSHostRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	zone, fqdn, recordType := d.Get("zone").(string), d.Get("fqdn").(string), d.Get("record_type").(string)
+	host, err := client.GetDDNSHost(ctx, zone, fqdn, recordType, d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn Dynamic DNS user %s of %s was deleted outside of Terraform", d.Id(), fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn Dynamic DNS user %s of %s: %s", d.Id(), fqdn, err)
+	}
+
+	d.Set("user_name", host.UserName)
+	return nil
+}
+
+func resourceDynDDNSHostDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone, fqdn, recordType := d.Get("zone").(string), d.Get("fqdn").(string), d.Get("record_type").(string)
+	log.Printf("[INFO] Deleting Dyn Dynamic DNS user %s of %s", d.Id(), fqdn)
+	if err := client.DeleteDDNSHost(ctx, zone, fqdn, recordType, d.Id()); err != nil {
+		return fmt.Errorf("Failed to delete Dyn Dynamic DNS user %s of %s: %s", d.Id(), fqdn, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynDDNS_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynDDNSDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynDDNSConfig, zone, "192.168.0.20", "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_ddns.foobar", "fqdn", "terraform-ddns."+zone),
+					resource.TestCheckResourceAttr("dyn_ddns.foobar", "current_address", "192.168.0.20"),
+					resource.TestCheckResourceAttr("dyn_ddns.foobar", "abuse_count", "0"),
+					resource.TestCheckResourceAttrSet("dyn_ddns_host.foobar", "user_name"),
+					resource.TestCheckResourceAttrSet("dyn_ddns_host.foobar", "password"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynDDNSConfig, zone, "192.168.0.21", "false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_ddns.foobar", "current_address", "192.168.0.21"),
+					resource.TestCheckResourceAttr("dyn_ddns.foobar", "active", "false"),
+				),
+			},
+			{
+				ResourceName:            "dyn_ddns.foobar",
+				ImportState:             true,
+				ImportStateId:           zone + "/terraform-ddns." + zone + "/A",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"publish"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynDDNSDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		var err error
+		switch rs.Type {
+		case "dyn_ddns":
+			_, err = client.GetDDNS(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.Attributes["fqdn"], rs.Primary.Attributes["record_type"])
+		case "dyn_ddns_host":
+			_, err = client.GetDDNSHost(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.Attributes["fqdn"], rs.Primary.Attributes["record_type"], rs.Primary.ID)
+		default:
+			continue
+		}
+
+		if err == nil {
+			return fmt.Errorf("%s still exists", rs.Type)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynDDNSConfig = `
+resource "dyn_ddns" "foobar" {
+	zone = "%s"
+	name = "terraform-ddns"
+	address = "%s"
+	active = %s
+}
+
+resource "dyn_ddns_host" "foobar" {
+	zone = "${dyn_ddns.foobar.zone}"
+	fqdn = "${dyn_ddns.foobar.fqdn}"
+}`
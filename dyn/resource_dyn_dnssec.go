@@ -0,0 +1,307 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynDNSSEC() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynDNSSECCreate,
+		Read:   resourceDynDNSSECRead,
+		Update: resourceDynDNSSECUpdate,
+		Delete: resourceDynDNSSECDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ksk": dnssecKeySchema(2048),
+
+			"zsk": dnssecKeySchema(1024),
+
+			"contact_nickname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"notify_events": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateStringInSlice(dnssecNotifyEvents),
+				},
+				Set: schema.HashString,
+			},
+
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ds_records": dsRecordsSchema(),
+		},
+	}
+}
+
+// dnssecKeySchema is the schema of the ksk and zsk blocks.
+func dnssecKeySchema(defaultBits int) *schema.Schema {
+	var algorithms []string
+	for a := range dnssecAlgorithms {
+		algorithms = append(algorithms, a)
+	}
+	sort.Strings(algorithms)
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"algorithm": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateStringInSlice(algorithms),
+				},
+				"bits": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultBits,
+					ValidateFunc: validateIntInSlice([]int{1024, 2048, 4096}),
+				},
+				"lifetime": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validateIntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// dsRecordsSchema is the schema of the DS records computed from the KSKs of
+// a zone.
+func dsRecordsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key_tag": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"algorithm": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"digest_type": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"digest": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"ds": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// resourceDynDNSSECRequest builds the signing configuration of the zone.
+func resourceDynDNSSECRequest(d *schema.ResourceData) *dnssecRequest {
+	config := &dnssecRequest{
+		ContactNickname: d.Get("contact_nickname").(string),
+	}
+
+	config.NotifyEvents = joinStringSet(d.Get("notify_events").(*schema.Set))
+
+	for _, keyType := range []string{"KSK", "ZSK"} {
+		m := d.Get(strings.ToLower(keyType) + ".0").(map[string]interface{})
+		key := dnssecKeyRequest{
+			Type:      keyType,
+			Algorithm: m["algorithm"].(string),
+			Bits:      strconv.Itoa(m["bits"].(int)),
+		}
+		if lifetime := m["lifetime"].(int); lifetime > 0 {
+			key.Lifetime = strconv.Itoa(lifetime)
+		}
+		config.Keys = append(config.Keys, key)
+	}
+	return config
+}
+
+func resourceDynDNSSECCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	log.Printf("[INFO] Setting up DNSSEC for Dyn zone %s", zone)
+	if err := client.CreateDNSSEC(ctx, zone, resourceDynDNSSECRequest(d)); err != nil {
+		return fmt.Errorf("Failed to set up DNSSEC for Dyn zone %s: %s", zone, err)
+	}
+	d.SetId(zone)
+
+	if d.Get("active").(bool) {
+		log.Printf("[INFO] Activating DNSSEC for Dyn zone %s", zone)
+		if err := client.ActivateDNSSEC(ctx, zone, true); err != nil {
+			return fmt.Errorf("Failed to activate DNSSEC for Dyn zone %s: %s", zone, err)
+		}
+	}
+
+	return resourceDynDNSSECReadContext(ctx, d, client)
+}
+
+func resourceDynDNSSECRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynDNSSECReadContext(ctx, d, client)
+}
+
+func resourceDynDNSSECReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone := d.Id()
+	config, err := client.GetDNSSEC(ctx, zone)
+	if isNotFound(err) {
+		log.Printf("[WARN] DNSSEC for Dyn zone %s was removed outside of Terraform", zone)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read DNSSEC for Dyn zone %s: %s", zone, err)
+	}
+
+	d.Set("zone", zone)
+	d.Set("contact_nickname", config.ContactNickname)
+	d.Set("active", config.Active == "Y")
+
+	d.Set("notify_events", splitStringSet(config.NotifyEvents))
+
+	for _, keyType := range []string{"KSK", "ZSK"} {
+		key := currentDNSSECKey(config.Keys, keyType)
+		if key == nil {
+			continue
+		}
+		bits, _ := strconv.Atoi(string(key.Bits))
+		lifetime, _ := strconv.Atoi(string(key.Lifetime))
+		d.Set(strings.ToLower(keyType), []map[string]interface{}{{
+			"algorithm": key.Algorithm,
+			"bits":      bits,
+			"lifetime":  lifetime,
+		}})
+	}
+
+	return d.Set("ds_records", flattenDSRecords(zone, config.Keys))
+}
+
+// currentDNSSECKey returns the key of keyType the zone is signed with,
+// preferring an active key over those being rolled in or out.
+func currentDNSSECKey(keys []DNSSECKey, keyType string) *DNSSECKey {
+	var current *DNSSECKey
+	for i := range keys {
+		key := &keys[i]
+		if key.Type != keyType {
+			continue
+		}
+		if current == nil || (key.Active == "Y" && current.Active != "Y") {
+			current = key
+		}
+	}
+	return current
+}
+
+// flattenDSRecords computes the DS records of the active KSKs of a zone.
+func flattenDSRecords(zone string, keys []DNSSECKey) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, key := range keys {
+		if key.Type != "KSK" || key.Active == "N" {
+			continue
+		}
+		ds, err := dnskeyDS(zone, key.DNSKey.DataBlock)
+		if err != nil {
+			log.Printf("[WARN] Failed to compute the DS record of KSK %s of Dyn zone %s: %s", key.ID, zone, err)
+			continue
+		}
+		records = append(records, map[string]interface{}{
+			"key_tag":     ds.KeyTag,
+			"algorithm":   ds.Algorithm,
+			"digest_type": ds.DigestType,
+			"digest":      ds.Digest,
+			"ds":          ds.String(),
+		})
+	}
+	return records
+}
+
+func resourceDynDNSSECUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	zone := d.Id()
+	if d.HasChange("ksk") || d.HasChange("zsk") || d.HasChange("contact_nickname") || d.HasChange("notify_events") {
+		log.Printf("[INFO] Updating DNSSEC for Dyn zone %s", zone)
+		if err := client.UpdateDNSSEC(ctx, zone, resourceDynDNSSECRequest(d)); err != nil {
+			return fmt.Errorf("Failed to update DNSSEC for Dyn zone %s: %s", zone, err)
+		}
+	}
+
+	if d.HasChange("active") {
+		active := d.Get("active").(bool)
+		log.Printf("[INFO] Setting DNSSEC for Dyn zone %s active: %t", zone, active)
+		if err := client.ActivateDNSSEC(ctx, zone, active); err != nil {
+			return fmt.Errorf("Failed to change the activation of DNSSEC for Dyn zone %s: %s", zone, err)
+		}
+	}
+
+	return resourceDynDNSSECReadContext(ctx, d, client)
+}
+
+func resourceDynDNSSECDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	log.Printf("[INFO] Removing DNSSEC from Dyn zone %s", d.Id())
+	if err := client.DeleteDNSSEC(ctx, d.Id()); err != nil {
+		return fmt.Errorf("Failed to remove DNSSEC from Dyn zone %s: %s", d.Id(), err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynDNSSEC_basic(t *testing.T) {
+	// signing a zone changes how resolvers validate it, so the test runs
+	// against a zone set aside for it
+	zone := os.Getenv("DYN_DNSSEC_ZONE")
+	if zone == "" {
+		t.Skip("DYN_DNSSEC_ZONE must be set to an unsigned zone for this acceptance test")
+	}
+	contact := testAccContactNickname(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynDNSSECDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynDNSSECConfig, zone, contact, "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_dnssec.foobar", "active", "true"),
+					resource.TestCheckResourceAttr("dyn_dnssec.foobar", "ksk.0.algorithm", "RSA/SHA-256"),
+					resource.TestCheckResourceAttr("dyn_dnssec.foobar", "ds_records.0.digest_type", "2"),
+					resource.TestCheckResourceAttrSet("dyn_dnssec.foobar", "ds_records.0.digest"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynDNSSECConfig, zone, contact, "false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_dnssec.foobar", "active", "false"),
+				),
+			},
+			{
+				ResourceName:      "dyn_dnssec.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynDNSSECDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_dnssec" {
+			continue
+		}
+
+		_, err := client.GetDNSSEC(context.Background(), rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("DNSSEC still set up")
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynDNSSECConfig = `
+resource "dyn_dnssec" "foobar" {
+	zone = "%s"
+	contact_nickname = "%s"
+	notify_events = ["create", "expire"]
+	active = %s
+
+	ksk {
+		algorithm = "RSA/SHA-256"
+	}
+
+	zsk {
+		algorithm = "RSA/SHA-256"
+	}
+}`
@@ -0,0 +1,436 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynGSLB() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynGSLBCreate,
+		Read:   resourceDynGSLBRead,
+		Update: resourceDynGSLBUpdate,
+		Delete: resourceDynGSLBDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynServiceImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRecordName,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"region": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region_code": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringInSlice(gslbRegionCodes),
+						},
+						"serve_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validateIntAtLeast(1),
+						},
+						"failover_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "global",
+							ValidateFunc: validateStringInSlice([]string{"ip", "cname", "region", "global"}),
+						},
+						"failover_data": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"pool": gslbPoolSchema(),
+					},
+				},
+			},
+
+			"monitor": monitorSchema(),
+
+			"contact_nickname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validateIntBetween(1, maxTTL),
+			},
+
+			"auto_recover": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"notify_events": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateStringInSlice([]string{"ip", "svc", "nosrv"}),
+				},
+				Set: schema.HashString,
+			},
+
+			"syslog_server": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"syslog_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      514,
+				ValidateFunc: validateIntBetween(1, 65535),
+			},
+
+			"syslog_ident": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "dynect",
+			},
+
+			"syslog_facility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "daemon",
+				ValidateFunc: validateStringInSlice(syslogFacilities),
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"publish": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+		},
+	}
+}
+
+// gslbPoolSchema is the schema of the pool of addresses of a region, shared
+// with RTTM.
+func gslbPoolSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"address": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"label": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"weight": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					ValidateFunc: validateIntBetween(1, 15),
+				},
+				"serve_mode": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "obey",
+					ValidateFunc: validateStringInSlice(gslbServeModes),
+				},
+				"status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// resourceDynGSLBRequest builds the service from the configuration.
+func resourceDynGSLBRequest(d *schema.ResourceData) *GSLB {
+	gslb := &GSLB{
+		ContactNickname: d.Get("contact_nickname").(string),
+		TTL:             looseString(strconv.Itoa(d.Get("ttl").(int))),
+		AutoRecover:     yesNo(d.Get("auto_recover").(bool)),
+		NotifyEvents:    joinStringSet(d.Get("notify_events").(*schema.Set)),
+		Monitor:         expandMonitor(d.Get("monitor")),
+		Publish:         "N",
+	}
+	if server := d.Get("syslog_server").(string); server != "" {
+		gslb.SyslogServer = server
+		gslb.SyslogPort = looseString(strconv.Itoa(d.Get("syslog_port").(int)))
+		gslb.SyslogIdent = d.Get("syslog_ident").(string)
+		gslb.SyslogFacility = d.Get("syslog_facility").(string)
+	}
+
+	for _, v := range d.Get("region").([]interface{}) {
+		m := v.(map[string]interface{})
+		region := GSLBRegion{
+			RegionCode:   m["region_code"].(string),
+			ServeCount:   looseString(strconv.Itoa(m["serve_count"].(int))),
+			FailoverMode: m["failover_mode"].(string),
+			FailoverData: m["failover_data"].(string),
+			Pool:         expandGSLBPool(m["pool"]),
+		}
+		gslb.Region = append(gslb.Region, region)
+	}
+	return gslb
+}
+
+// expandGSLBPool builds the addresses of a pool block.
+func expandGSLBPool(v interface{}) []GSLBAddress {
+	var pool []GSLBAddress
+	for _, p := range v.([]interface{}) {
+		a := p.(map[string]interface{})
+		pool = append(pool, GSLBAddress{
+			Address:   a["address"].(string),
+			Label:     a["label"].(string),
+			Weight:    looseString(strconv.Itoa(a["weight"].(int))),
+			ServeMode: a["serve_mode"].(string),
+		})
+	}
+	return pool
+}
+
+// flattenGSLBPool renders the addresses of a pool, with their health, in
+// the order they are configured, followed by those added outside of
+// Terraform.
+func flattenGSLBPool(pool []GSLBAddress, configured []interface{}) []map[string]interface{} {
+	order := configuredOrder(configured, "address")
+	sorted := make([]GSLBAddress, len(pool))
+	copy(sorted, pool)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order.less(sorted[i].Address, sorted[j].Address)
+	})
+
+	flattened := make([]map[string]interface{}, len(sorted))
+	for i, a := range sorted {
+		flattened[i] = map[string]interface{}{
+			"address":    a.Address,
+			"label":      a.Label,
+			"weight":     looseInt(a.Weight),
+			"serve_mode": a.ServeMode,
+			"status":     a.Status,
+		}
+	}
+	return flattened
+}
+
+// configuredPools maps the region codes of configured regions to the
+// addresses of their pools.
+func configuredPools(configured []interface{}) map[string][]interface{} {
+	pools := make(map[string][]interface{})
+	for _, v := range configured {
+		if m, ok := v.(map[string]interface{}); ok {
+			pool, _ := m["pool"].([]interface{})
+			pools[m["region_code"].(string)] = pool
+		}
+	}
+	return pools
+}
+
+// resourceDynGSLBNotes renders the note attached to the publishes of the
+// service at fqdn.
+func resourceDynGSLBNotes(d *schema.ResourceData, client *Client, zone, fqdn string) (string, error) {
+	return renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_gslb "+fqdn, zone)
+}
+
+func resourceDynGSLBCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	fqdn := recordFQDN(d.Get("name").(string), zone)
+	notes, err := resourceDynGSLBNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating Dyn GSLB service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.CreateGSLB(ctx, zone, fqdn, resourceDynGSLBRequest(d)); err != nil {
+			return fmt.Errorf("Failed to create Dyn GSLB service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(fqdn)
+
+	return resourceDynGSLBReadContext(ctx, d, client)
+}
+
+func resourceDynGSLBRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynGSLBReadContext(ctx, d, client)
+}
+
+func resourceDynGSLBReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	gslb, err := client.GetGSLB(ctx, zone, fqdn)
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn GSLB service %s was deleted outside of Terraform", fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn GSLB service %s: %s", fqdn, err)
+	}
+
+	d.Set("fqdn", fqdn)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("status", gslb.Status)
+	d.Set("contact_nickname", gslb.ContactNickname)
+	d.Set("ttl", looseInt(gslb.TTL))
+	d.Set("auto_recover", gslb.AutoRecover != "N")
+	d.Set("notify_events", splitStringSet(gslb.NotifyEvents))
+	if gslb.SyslogServer != "" {
+		d.Set("syslog_server", gslb.SyslogServer)
+		d.Set("syslog_port", looseInt(gslb.SyslogPort))
+		d.Set("syslog_ident", gslb.SyslogIdent)
+		d.Set("syslog_facility", gslb.SyslogFacility)
+	} else {
+		d.Set("syslog_server", "")
+	}
+	if err := d.Set("monitor", flattenMonitor(gslb.Monitor)); err != nil {
+		return err
+	}
+	return d.Set("region", flattenGSLBRegions(gslb.Region, d.Get("region").([]interface{})))
+}
+
+// flattenGSLBRegions renders the regions of a service in the order they are
+// configured, followed by those added outside of Terraform, so that the
+// order Dyn returns them in does not show up as a diff.
+func flattenGSLBRegions(regions []GSLBRegion, configured []interface{}) []map[string]interface{} {
+	order := configuredOrder(configured, "region_code")
+	pools := configuredPools(configured)
+	sorted := make([]GSLBRegion, len(regions))
+	copy(sorted, regions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order.less(sorted[i].RegionCode, sorted[j].RegionCode)
+	})
+
+	flattened := make([]map[string]interface{}, len(sorted))
+	for i, r := range sorted {
+		flattened[i] = map[string]interface{}{
+			"region_code":   r.RegionCode,
+			"serve_count":   looseInt(r.ServeCount),
+			"failover_mode": r.FailoverMode,
+			"failover_data": r.FailoverData,
+			"pool":          flattenGSLBPool(r.Pool, pools[r.RegionCode]),
+		}
+	}
+	return flattened
+}
+
+func resourceDynGSLBUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	if !d.HasChange("region") && !d.HasChange("monitor") && !d.HasChange("contact_nickname") &&
+		!d.HasChange("ttl") && !d.HasChange("auto_recover") && !d.HasChange("notify_events") &&
+		!d.HasChange("syslog_server") && !d.HasChange("syslog_port") && !d.HasChange("syslog_ident") &&
+		!d.HasChange("syslog_facility") {
+		// publish and publish_notes only apply to later changes
+		return resourceDynGSLBReadContext(ctx, d, client)
+	}
+
+	notes, err := resourceDynGSLBNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating Dyn GSLB service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.UpdateGSLB(ctx, zone, fqdn, resourceDynGSLBRequest(d)); err != nil {
+			return fmt.Errorf("Failed to update Dyn GSLB service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceDynGSLBReadContext(ctx, d, client)
+}
+
+func resourceDynGSLBDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	notes, err := resourceDynGSLBNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Dyn GSLB service %s", fqdn)
+	return stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.DeleteGSLB(ctx, zone, fqdn); err != nil {
+			return fmt.Errorf("Failed to delete Dyn GSLB service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,91 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynGSLB_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+	contact := testAccContactNickname(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynGSLBDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynGSLBConfig, zone, contact, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_gslb.foobar", "fqdn", "terraform-gslb."+zone),
+					resource.TestCheckResourceAttr("dyn_gslb.foobar", "region.0.pool.#", "2"),
+					resource.TestCheckResourceAttrSet("dyn_gslb.foobar", "region.0.pool.0.status"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynGSLBConfig, zone, contact, 5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_gslb.foobar", "region.0.pool.1.weight", "5"),
+				),
+			},
+			{
+				ResourceName:            "dyn_gslb.foobar",
+				ImportState:             true,
+				ImportStateId:           zone + "/terraform-gslb." + zone,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"publish"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynGSLBDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_gslb" {
+			continue
+		}
+
+		_, err := client.GetGSLB(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("GSLB service still exists")
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynGSLBConfig = `
+resource "dyn_gslb" "foobar" {
+	zone = "%s"
+	name = "terraform-gslb"
+	contact_nickname = "%s"
+
+	region {
+		region_code = "global"
+
+		pool {
+			address = "192.168.0.10"
+			weight = 10
+		}
+
+		pool {
+			address = "192.168.0.11"
+			weight = %d
+		}
+	}
+
+	monitor {
+		protocol = "HTTP"
+		path = "/"
+	}
+}`
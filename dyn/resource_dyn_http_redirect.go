@@ -0,0 +1,215 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynHTTPRedirect() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynHTTPRedirectCreate,
+		Read:   resourceDynHTTPRedirectRead,
+		Update: resourceDynHTTPRedirectUpdate,
+		Delete: resourceDynHTTPRedirectDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynServiceImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRecordName,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateHTTPURL,
+			},
+
+			"code": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      301,
+				ValidateFunc: validateIntInSlice([]int{301, 302}),
+			},
+
+			"keep_uri": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"publish": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+		},
+	}
+}
+
+// resourceDynHTTPRedirectRequest builds the redirect from the configuration.
+func resourceDynHTTPRedirectRequest(d *schema.ResourceData) *httpRedirectRequest {
+	return &httpRedirectRequest{
+		Code:    strconv.Itoa(d.Get("code").(int)),
+		KeepURI: yesNo(d.Get("keep_uri").(bool)),
+		URL:     d.Get("url").(string),
+		Publish: "N",
+	}
+}
+
+// resourceDynHTTPRedirectNotes renders the note attached to the publishes of
+// the redirect at fqdn.
+func resourceDynHTTPRedirectNotes(d *schema.ResourceData, client *Client, zone, fqdn string) (string, error) {
+	return renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_http_redirect "+fqdn, zone)
+}
+
+func resourceDynHTTPRedirectCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	fqdn := recordFQDN(d.Get("name").(string), zone)
+	notes, err := resourceDynHTTPRedirectNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating Dyn HTTP redirect %s to %s", fqdn, d.Get("url").(string))
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.CreateHTTPRedirect(ctx, zone, fqdn, resourceDynHTTPRedirectRequest(d)); err != nil {
+			return fmt.Errorf("Failed to create Dyn HTTP redirect %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(fqdn)
+
+	return resourceDynHTTPRedirectReadContext(ctx, d, client)
+}
+
+func resourceDynHTTPRedirectRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynHTTPRedirectReadContext(ctx, d, client)
+}
+
+func resourceDynHTTPRedirectReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	redirect, err := client.GetHTTPRedirect(ctx, zone, fqdn)
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn HTTP redirect %s was deleted outside of Terraform", fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn HTTP redirect %s: %s", fqdn, err)
+	}
+
+	code, err := strconv.Atoi(string(redirect.Code))
+	if err != nil {
+		return fmt.Errorf("Dyn HTTP redirect %s has an invalid code %q", fqdn, redirect.Code)
+	}
+
+	d.Set("fqdn", fqdn)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("url", redirect.URL)
+	d.Set("code", code)
+	d.Set("keep_uri", redirect.KeepURI == "Y")
+	return nil
+}
+
+func resourceDynHTTPRedirectUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	if !d.HasChange("url") && !d.HasChange("code") && !d.HasChange("keep_uri") {
+		// publish and publish_notes only apply to later changes
+		return resourceDynHTTPRedirectReadContext(ctx, d, client)
+	}
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	notes, err := resourceDynHTTPRedirectNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating Dyn HTTP redirect %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.UpdateHTTPRedirect(ctx, zone, fqdn, resourceDynHTTPRedirectRequest(d)); err != nil {
+			return fmt.Errorf("Failed to update Dyn HTTP redirect %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceDynHTTPRedirectReadContext(ctx, d, client)
+}
+
+func resourceDynHTTPRedirectDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	notes, err := resourceDynHTTPRedirectNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Dyn HTTP redirect %s", fqdn)
+	return stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.DeleteHTTPRedirect(ctx, zone, fqdn); err != nil {
+			return fmt.Errorf("Failed to delete Dyn HTTP redirect %s: %s", fqdn, err)
+		}
+		return nil
+	})
+}
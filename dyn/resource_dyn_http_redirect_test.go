@@ -0,0 +1,74 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynHTTPRedirect_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynHTTPRedirectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynHTTPRedirectConfig, zone, 301, "false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "fqdn", "terraform-redirect."+zone),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "code", "301"),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "keep_uri", "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynHTTPRedirectConfig, zone, 302, "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "code", "302"),
+					resource.TestCheckResourceAttr("dyn_http_redirect.foobar", "keep_uri", "true"),
+				),
+			},
+			{
+				ResourceName:            "dyn_http_redirect.foobar",
+				ImportState:             true,
+				ImportStateId:           zone + "/terraform-redirect." + zone,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"publish"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynHTTPRedirectDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_http_redirect" {
+			continue
+		}
+
+		_, err := client.GetHTTPRedirect(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("HTTP redirect still exists")
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynHTTPRedirectConfig = `
+resource "dyn_http_redirect" "foobar" {
+	zone = "%s"
+	name = "terraform-redirect"
+	url = "https://www.example.org/"
+	code = %d
+	keep_uri = %s
+}`
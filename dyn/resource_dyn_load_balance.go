@@ -0,0 +1,392 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynLoadBalance() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynLoadBalanceCreate,
+		Read:   resourceDynLoadBalanceRead,
+		Update: resourceDynLoadBalanceUpdate,
+		Delete: resourceDynLoadBalanceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynServiceImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRecordName,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"pool": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"weight": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validateIntBetween(1, 15),
+						},
+						"serve_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "obey",
+							ValidateFunc: validateStringInSlice(gslbServeModes),
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"serve_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validateIntAtLeast(1),
+			},
+
+			"failover_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "global",
+				ValidateFunc: validateStringInSlice([]string{"ip", "cname", "global"}),
+			},
+
+			"failover_data": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"monitor": monitorSchema(),
+
+			"contact_nickname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validateIntBetween(1, maxTTL),
+			},
+
+			"auto_recover": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"notify_events": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateStringInSlice([]string{"ip", "svc", "nosrv"}),
+				},
+				Set: schema.HashString,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"publish": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+		},
+	}
+}
+
+// resourceDynLoadBalanceRequest builds the service from the configuration,
+// without its pool.
+func resourceDynLoadBalanceRequest(d *schema.ResourceData) *LoadBalance {
+	return &LoadBalance{
+		ServeCount:      looseString(strconv.Itoa(d.Get("serve_count").(int))),
+		FailoverMode:    d.Get("failover_mode").(string),
+		FailoverData:    d.Get("failover_data").(string),
+		ContactNickname: d.Get("contact_nickname").(string),
+		TTL:             looseString(strconv.Itoa(d.Get("ttl").(int))),
+		AutoRecover:     yesNo(d.Get("auto_recover").(bool)),
+		NotifyEvents:    joinStringSet(d.Get("notify_events").(*schema.Set)),
+		Monitor:         expandMonitor(d.Get("monitor")),
+		Publish:         "N",
+	}
+}
+
+// expandLoadBalancePool builds the entries of a pool block.
+func expandLoadBalancePool(v interface{}) []LoadBalanceEntry {
+	var pool []LoadBalanceEntry
+	for _, p := range v.([]interface{}) {
+		m := p.(map[string]interface{})
+		pool = append(pool, LoadBalanceEntry{
+			Address:   m["address"].(string),
+			Label:     m["label"].(string),
+			Weight:    looseString(strconv.Itoa(m["weight"].(int))),
+			ServeMode: m["serve_mode"].(string),
+		})
+	}
+	return pool
+}
+
+// diffLoadBalancePool compares two pools by address, and returns the entries
+// to add, those to update and the addresses to remove.
+func diffLoadBalancePool(current, desired []LoadBalanceEntry) (added, changed []LoadBalanceEntry, removed []string) {
+	existing := make(map[string]LoadBalanceEntry)
+	for _, e := range current {
+		existing[e.Address] = e
+	}
+	kept := make(map[string]bool)
+	for _, e := range desired {
+		kept[e.Address] = true
+		o, ok := existing[e.Address]
+		switch {
+		case !ok:
+			added = append(added, e)
+		case o.Label != e.Label || o.Weight != e.Weight || o.ServeMode != e.ServeMode:
+			changed = append(changed, e)
+		}
+	}
+	for _, e := range current {
+		if !kept[e.Address] {
+			removed = append(removed, e.Address)
+		}
+	}
+	return added, changed, removed
+}
+
+// resourceDynLoadBalanceNotes renders the note attached to the publishes of
+// the service at fqdn.
+func resourceDynLoadBalanceNotes(d *schema.ResourceData, client *Client, zone, fqdn string) (string, error) {
+	return renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_load_balance "+fqdn, zone)
+}
+
+func resourceDynLoadBalanceCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	fqdn := recordFQDN(d.Get("name").(string), zone)
+	notes, err := resourceDynLoadBalanceNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	lb := resourceDynLoadBalanceRequest(d)
+	lb.Pool = expandLoadBalancePool(d.Get("pool"))
+	log.Printf("[INFO] Creating Dyn Load Balancing service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.CreateLoadBalance(ctx, zone, fqdn, lb); err != nil {
+			return fmt.Errorf("Failed to create Dyn Load Balancing service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(fqdn)
+
+	return resourceDynLoadBalanceReadContext(ctx, d, client)
+}
+
+func resourceDynLoadBalanceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynLoadBalanceReadContext(ctx, d, client)
+}
+
+func resourceDynLoadBalanceReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	lb, err := client.GetLoadBalance(ctx, zone, fqdn)
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn Load Balancing service %s was deleted outside of Terraform", fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn Load Balancing service %s: %s", fqdn, err)
+	}
+
+	d.Set("fqdn", fqdn)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("status", lb.Status)
+	d.Set("serve_count", looseInt(lb.ServeCount))
+	d.Set("failover_mode", lb.FailoverMode)
+	d.Set("failover_data", lb.FailoverData)
+	d.Set("contact_nickname", lb.ContactNickname)
+	d.Set("ttl", looseInt(lb.TTL))
+	d.Set("auto_recover", lb.AutoRecover != "N")
+	d.Set("notify_events", splitStringSet(lb.NotifyEvents))
+	if err := d.Set("monitor", flattenMonitor(lb.Monitor)); err != nil {
+		return err
+	}
+	return d.Set("pool", flattenLoadBalancePool(lb.Pool, d.Get("pool").([]interface{})))
+}
+
+// flattenLoadBalancePool renders the pool of a service in the order its
+// addresses are configured, followed by those added outside of Terraform.
+func flattenLoadBalancePool(pool []LoadBalanceEntry, configured []interface{}) []map[string]interface{} {
+	order := configuredOrder(configured, "address")
+	sorted := make([]LoadBalanceEntry, len(pool))
+	copy(sorted, pool)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order.less(sorted[i].Address, sorted[j].Address)
+	})
+
+	flattened := make([]map[string]interface{}, len(sorted))
+	for i, e := range sorted {
+		flattened[i] = map[string]interface{}{
+			"address":    e.Address,
+			"label":      e.Label,
+			"weight":     looseInt(e.Weight),
+			"serve_mode": e.ServeMode,
+			"status":     e.Status,
+		}
+	}
+	return flattened
+}
+
+// resourceDynLoadBalanceUpdate stages the changes of the pool entry by
+// entry, so that the other addresses keep being served as they are, and
+// updates the rest of the service only when it changed.
+func resourceDynLoadBalanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	serviceChanged := d.HasChange("serve_count") || d.HasChange("failover_mode") || d.HasChange("failover_data") ||
+		d.HasChange("monitor") || d.HasChange("contact_nickname") || d.HasChange("ttl") ||
+		d.HasChange("auto_recover") || d.HasChange("notify_events")
+	oldPool, newPool := d.GetChange("pool")
+	added, changed, removed := diffLoadBalancePool(expandLoadBalancePool(oldPool), expandLoadBalancePool(newPool))
+	if !serviceChanged && len(added)+len(changed)+len(removed) == 0 {
+		// publish and publish_notes only apply to later changes
+		return resourceDynLoadBalanceReadContext(ctx, d, client)
+	}
+
+	notes, err := resourceDynLoadBalanceNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating Dyn Load Balancing service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if serviceChanged {
+			if err := client.UpdateLoadBalance(ctx, zone, fqdn, resourceDynLoadBalanceRequest(d)); err != nil {
+				return fmt.Errorf("Failed to update Dyn Load Balancing service %s: %s", fqdn, err)
+			}
+		}
+		// addresses are added before others are removed, so that the pool
+		// is never empty
+		for _, e := range added {
+			e.Publish = "N"
+			log.Printf("[DEBUG] Adding %s to the pool of Dyn Load Balancing service %s", e.Address, fqdn)
+			if err := client.CreateLoadBalanceEntry(ctx, zone, fqdn, &e); err != nil {
+				return fmt.Errorf("Failed to add %s to Dyn Load Balancing service %s: %s", e.Address, fqdn, err)
+			}
+		}
+		for _, e := range changed {
+			e.Publish = "N"
+			log.Printf("[DEBUG] Updating %s in the pool of Dyn Load Balancing service %s", e.Address, fqdn)
+			if err := client.UpdateLoadBalanceEntry(ctx, zone, fqdn, e.Address, &e); err != nil {
+				return fmt.Errorf("Failed to update %s in Dyn Load Balancing service %s: %s", e.Address, fqdn, err)
+			}
+		}
+		for _, address := range removed {
+			log.Printf("[DEBUG] Removing %s from the pool of Dyn Load Balancing service %s", address, fqdn)
+			if err := client.DeleteLoadBalanceEntry(ctx, zone, fqdn, address); err != nil {
+				return fmt.Errorf("Failed to remove %s from Dyn Load Balancing service %s: %s", address, fqdn, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceDynLoadBalanceReadContext(ctx, d, client)
+}
+
+func resourceDynLoadBalanceDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	notes, err := resourceDynLoadBalanceNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Dyn Load Balancing service %s", fqdn)
+	return stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.DeleteLoadBalance(ctx, zone, fqdn); err != nil {
+			return fmt.Errorf("Failed to delete Dyn Load Balancing service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+}
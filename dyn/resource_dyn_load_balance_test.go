@@ -0,0 +1,87 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynLoadBalance_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+	contact := testAccContactNickname(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynLoadBalanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynLoadBalanceConfig, zone, contact, 1, "192.168.0.11"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_load_balance.foobar", "fqdn", "terraform-lb."+zone),
+					resource.TestCheckResourceAttr("dyn_load_balance.foobar", "pool.#", "2"),
+					resource.TestCheckResourceAttrSet("dyn_load_balance.foobar", "pool.0.status"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynLoadBalanceConfig, zone, contact, 5, "192.168.0.12"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_load_balance.foobar", "pool.0.weight", "5"),
+					resource.TestCheckResourceAttr("dyn_load_balance.foobar", "pool.1.address", "192.168.0.12"),
+				),
+			},
+			{
+				ResourceName:            "dyn_load_balance.foobar",
+				ImportState:             true,
+				ImportStateId:           zone + "/terraform-lb." + zone,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"publish"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynLoadBalanceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_load_balance" {
+			continue
+		}
+
+		_, err := client.GetLoadBalance(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Load Balancing service still exists")
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynLoadBalanceConfig = `
+resource "dyn_load_balance" "foobar" {
+	zone = "%s"
+	name = "terraform-lb"
+	contact_nickname = "%s"
+
+	pool {
+		address = "192.168.0.10"
+		weight = %d
+	}
+
+	pool {
+		address = "%s"
+	}
+
+	monitor {
+		protocol = "HTTP"
+		path = "/"
+	}
+}`
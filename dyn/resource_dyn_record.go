@@ -1,10 +1,14 @@
 package dyn
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/nesv/go-dynect/dynect"
@@ -12,6 +16,14 @@ import (
 
 var mutex = &sync.Mutex{}
 
+// unpublishedRecordID stands for the ID of a record that was created in a
+// zone published manually, until the zone is published and the record gets
+// its own.
+const unpublishedRecordID = "0"
+
+// supportedRecordTypes are the record types dyn_record can manage.
+var supportedRecordTypes = []string{"A", "AAAA", "ALIAS", "CNAME", "MX", "NS", "PTR", "SOA", "SPF", "SRV", "TXT"}
+
 func resourceDynRecord() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDynRecordCreate,
@@ -22,6 +34,23 @@ func resourceDynRecord() *schema.Resource {
 			State: resourceDynRecordImportState,
 		},
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDynRecordV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDynRecordStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
+		CustomizeDiff: resourceDynRecordCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"zone": {
 				Type:     schema.TypeString,
@@ -30,17 +59,14 @@ func resourceDynRecord() *schema.Resource {
 			},
 
 			"name": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRecordName,
 				DiffSuppressFunc: func(k, oldV, newV string, d *schema.ResourceData) bool {
-					// Records for top level domain
+					// Records for top level domain are read back with the
+					// zone as their name
 					zone := d.Get("zone").(string)
-					if oldV == zone && newV == "" {
-						return true
-					}
-
-					return oldV == newV
+					return normalizeRecordName(oldV, zone) == normalizeRecordName(newV, zone)
 				},
 			},
 
@@ -50,9 +76,9 @@ func resourceDynRecord() *schema.Resource {
 			},
 
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateStringInSlice(supportedRecordTypes),
 			},
 
 			"value": {
@@ -60,59 +86,294 @@ func resourceDynRecord() *schema.Resource {
 				Required: true,
 				DiffSuppressFunc: func(k, oldV, newV string, d *schema.ResourceData) bool {
 					recordType := d.Get("type").(string)
-					if recordType == "CNAME" || recordType == "NS" || recordType == "MX" {
-						// We expect FQDN here, which may or may not have a trailing dot
-						if !strings.HasSuffix(oldV, ".") {
-							oldV += "."
-						}
-						if !strings.HasSuffix(newV, ".") {
-							newV += "."
-						}
-					}
-
-					return oldV == newV
+					return normalizeRecordValue(recordType, oldV) == normalizeRecordValue(recordType, newV)
 				},
 			},
 
 			"ttl": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateTTL,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+
+			"pending_job_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"pending_publish": {
+				Type:     schema.TypeBool,
 				Computed: true,
 			},
 		},
 	}
 }
 
+func resourceDynRecordCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("name") || !d.NewValueKnown("zone") {
+		return nil
+	}
+
+	recordType := d.Get("type").(string)
+	name := d.Get("name").(string)
+	zone := d.Get("zone").(string)
+
+	if d.NewValueKnown("value") {
+		if err := checkRecordValue(recordType, d.Get("value").(string)); err != nil {
+			return fmt.Errorf("invalid value for %s record: %s", recordType, err)
+		}
+	}
+
+	if recordType == "CNAME" && normalizeRecordName(name, zone) == "" {
+		return fmt.Errorf("a CNAME record cannot be created at the apex of %s", zone)
+	}
+
+	if d.Id() != "" {
+		// Renames and changes between compatible types are made in place by
+		// Update, any other type change replaces the record.
+		if o, n := d.GetChange("type"); o.(string) != n.(string) && !compatibleRecordTypes(o.(string), n.(string)) {
+			if err := d.ForceNew("type"); err != nil {
+				return err
+			}
+		}
+		if d.HasChange("name") {
+			if err := d.SetNewComputed("fqdn"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 0 inherits the zone default, which is read back as the actual TTL of
+	// the record
+	if d.Id() == "" && d.NewValueKnown("ttl") && d.Get("ttl").(int) == 0 {
+		if err := d.SetNewComputed("ttl"); err != nil {
+			return err
+		}
+	}
+
+	client, ok := meta.(*Client)
+	if !ok {
+		return nil
+	}
+
+	if err := resourceDynRecordDiffTTL(d, client); err != nil {
+		return err
+	}
+
+	if d.Id() == "" || d.HasChange("name") || d.HasChange("type") {
+		if err := resourceDynRecordCheckNode(d, client, recordFQDN(name, zone), recordType); err != nil {
+			return err
+		}
+	}
+
+	// show unpublished changes that would be published along with this one
+	// at plan time already
+	if client.autoPublishes(zone) && resourceDynRecordHasChanges(d) {
+		_, err := checkForeignZoneChanges(client.StopContext(), client, zone)
+		return err
+	}
+	return nil
+}
+
+// resourceDynRecordDiffTTL leaves a TTL of 0 out of the diff when the record
+// already has the default TTL of its zone, which it inherits. Otherwise the
+// record is updated with the default TTL.
+func resourceDynRecordDiffTTL(d *schema.ResourceDiff, client *Client) error {
+	if d.Id() == "" || !d.HasChange("ttl") || !d.NewValueKnown("ttl") {
+		return nil
+	}
+	o, n := d.GetChange("ttl")
+	if n.(int) != 0 {
+		return nil
+	}
+	zoneTTL, err := client.GetZoneDefaultTTL(client.StopContext(), d.Get("zone").(string))
+	if err != nil {
+		return fmt.Errorf("Failed to read the default TTL of Dyn zone %s: %s", d.Get("zone").(string), err)
+	}
+	if o.(int) == zoneTTL {
+		return d.Clear("ttl")
+	}
+	return nil
+}
+
+// resourceDynRecordDefaultTTL sets the TTL of a record updated with a TTL of
+// 0 to the default TTL of its zone. An empty TTL is left out of the request,
+// which would keep the current TTL of the record.
+func resourceDynRecordDefaultTTL(ctx context.Context, client *Client, record *dynect.Record) error {
+	if record.TTL != "" {
+		return nil
+	}
+	ttl, err := client.GetZoneDefaultTTL(ctx, record.Zone)
+	if err != nil {
+		return fmt.Errorf("Failed to read the default TTL of Dyn zone %s: %s", record.Zone, err)
+	}
+	record.TTL = strconv.Itoa(ttl)
+	return nil
+}
+
+// resourceDynRecordCheckNode checks that the record does not put a CNAME
+// next to other records at fqdn, against the records live there. The record
+// itself is left out when it stays at the same node.
+func resourceDynRecordCheckNode(d *schema.ResourceDiff, client *Client, fqdn, recordType string) error {
+	zone := d.Get("zone").(string)
+	live, err := client.GetNodeRecordTypes(client.StopContext(), zone, fqdn)
+	if err != nil {
+		return fmt.Errorf("Failed to list the Dyn records of %s: %s", fqdn, err)
+	}
+	if oldFQDN, _ := d.GetChange("fqdn"); d.Id() != "" && strings.EqualFold(oldFQDN.(string), fqdn) {
+		oldType, _ := d.GetChange("type")
+		for i, t := range live {
+			if t == oldType.(string) {
+				live = append(live[:i:i], live[i+1:]...)
+				break
+			}
+		}
+	}
+	return checkCNAMEConflict(fqdn, recordType, live)
+}
+
+// resourceDynRecordHasChanges reports whether applying the diff will publish
+// the zone of the record.
+func resourceDynRecordHasChanges(d *schema.ResourceDiff) bool {
+	if d.Id() == "" {
+		return true
+	}
+	for _, k := range []string{"name", "type", "value", "ttl"} {
+		if d.HasChange(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// addressRecordTypes can replace one another in place, as they all answer
+// queries for the node with addresses.
+var addressRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"ALIAS": true,
+}
+
+// compatibleRecordTypes reports whether a record can change from one type
+// to the other without being replaced.
+func compatibleRecordTypes(from, to string) bool {
+	return addressRecordTypes[from] && addressRecordTypes[to]
+}
+
+// recordFQDN builds the FQDN of a record from its name and zone.
+func recordFQDN(name, zone string) string {
+	zone = strings.TrimSuffix(zone, ".")
+	if name = normalizeRecordName(name, zone); name == "" {
+		return zone
+	}
+	return fmt.Sprintf("%s.%s", name, zone)
+}
+
+// checkCNAMEConflict fails if a record of the given type at fqdn would put a
+// CNAME next to any other record, given the types of the live records there.
+func checkCNAMEConflict(fqdn, recordType string, live []string) error {
+	others := make(map[string]bool)
+	hasCNAME := false
+	for _, t := range live {
+		if t == "CNAME" {
+			hasCNAME = true
+		} else {
+			others[t] = true
+		}
+	}
+
+	if recordType == "CNAME" && len(others) > 0 {
+		var types []string
+		for t := range others {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return fmt.Errorf("%s cannot have a CNAME record alongside %s records", fqdn, strings.Join(types, ", "))
+	}
+	if recordType != "CNAME" && hasCNAME {
+		return fmt.Errorf("%s cannot have a %s record alongside its CNAME record", fqdn, recordType)
+	}
+	return nil
+}
+
 func resourceDynRecordCreate(d *schema.ResourceData, meta interface{}) error {
 	mutex.Lock()
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	record := &dynect.Record{
 		Name:  d.Get("name").(string),
 		Zone:  d.Get("zone").(string),
 		Type:  d.Get("type").(string),
-		TTL:   d.Get("ttl").(string),
+		TTL:   recordTTL(d.Get("ttl").(int)),
 		Value: d.Get("value").(string),
 	}
 	log.Printf("[DEBUG] Dyn record create configuration: %#v", record)
 
-	// create the record
-	err := client.CreateRecord(record)
+	notes, err := resourceDynRecordNotes(d, client, record)
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	// records planned together are only checked against each other now,
+	// as the plan only saw the records that were live then
+	fqdn := recordFQDN(record.Name, record.Zone)
+	live, err := client.GetNodeRecordTypes(ctx, record.Zone, fqdn)
 	if err != nil {
 		mutex.Unlock()
-		return fmt.Errorf("Failed to create Dyn record: %s", err)
+		return fmt.Errorf("Failed to list the Dyn records of %s: %s", fqdn, err)
+	}
+	if err := checkCNAMEConflict(fqdn, record.Type, live); err != nil {
+		mutex.Unlock()
+		return err
 	}
 
-	// publish the zone
-	err = client.PublishZone(record.Zone)
+	// create the record and publish the zone
+	err = publishZoneChanges(ctx, client, record.Zone, notes, func() error {
+		if err := client.CreateRecord(ctx, record); err != nil {
+			return fmt.Errorf("Failed to create Dyn record: %s", err)
+		}
+		return nil
+	})
 	if err != nil {
+		if pendingJobID(err) != 0 {
+			// Keep track of the record, so the publish can be waited on later.
+			lookupCtx, lookupCancel := context.WithTimeout(client.StopContext(), time.Minute)
+			if client.GetRecordID(lookupCtx, record) == nil {
+				d.SetId(record.ID)
+				d.Set("fqdn", recordFQDN(record.Name, record.Zone))
+			}
+			lookupCancel()
+		}
 		mutex.Unlock()
-		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+		err = resourceDynRecordJobError(d, err)
+		if d.Id() != "" {
+			// the record exists, an error would taint it: the next refresh
+			// waits for the publish instead, and removes the record from
+			// state if the publish failed
+			return nil
+		}
+		return err
+	}
+
+	if !client.autoPublishes(record.Zone) {
+		resourceDynRecordStaged(ctx, d, client, record)
+		mutex.Unlock()
+		return nil
 	}
 
 	// get the record ID
-	err = client.GetRecordID(record)
+	err = client.GetRecordID(ctx, record)
 	if err != nil {
 		mutex.Unlock()
 		return fmt.Errorf("%s", err)
@@ -123,22 +384,96 @@ func resourceDynRecordCreate(d *schema.ResourceData, meta interface{}) error {
 	return resourceDynRecordRead(d, meta)
 }
 
+// resourceDynRecordStaged records the state of a change that was staged on a
+// zone published manually. The live zone does not have the change yet, so
+// the state follows the configuration until the change is published.
+func resourceDynRecordStaged(ctx context.Context, d *schema.ResourceData, client *Client, record *dynect.Record) {
+	record.FQDN = recordFQDN(record.Name, record.Zone)
+	if record.ID == "" {
+		// a new record can only be found in the session that staged it
+		if err := client.GetRecordID(ctx, record); err != nil {
+			log.Printf("[DEBUG] Dyn record %s %s has no ID until it is published: %s", record.Type, record.FQDN, err)
+			record.ID = unpublishedRecordID
+		}
+	}
+
+	d.SetId(record.ID)
+	d.Set("fqdn", record.FQDN)
+	d.Set("value", normalizeRecordValue(record.Type, record.Value))
+	d.Set("pending_publish", true)
+}
+
+// resourceDynRecordUnpublished fails a change to a record whose creation has
+// not been published yet, which the Dyn API cannot address.
+func resourceDynRecordUnpublished(d *schema.ResourceData) error {
+	if d.Id() != unpublishedRecordID {
+		return nil
+	}
+	return fmt.Errorf("Dyn record %s %s has not been published yet: publish or discard the pending changes of zone %s first",
+		d.Get("type").(string), d.Get("fqdn").(string), d.Get("zone").(string))
+}
+
 func resourceDynRecordRead(d *schema.ResourceData, meta interface{}) error {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	record := &dynect.Record{
 		ID:   d.Id(),
 		Name: d.Get("name").(string),
 		Zone: d.Get("zone").(string),
-		TTL:  d.Get("ttl").(string),
+		TTL:  recordTTL(d.Get("ttl").(int)),
 		FQDN: d.Get("fqdn").(string),
 		Type: d.Get("type").(string),
 	}
 
-	err := client.GetRecord(record)
+	// resume waiting on a publish that was still running at the end of the
+	// last run
+	if jobID := d.Get("pending_job_id").(int); jobID != 0 {
+		log.Printf("[INFO] Waiting for pending Dyn job %d", jobID)
+		err := client.WaitJob(ctx, jobID, nil)
+		if pendingJobID(err) != 0 {
+			return fmt.Errorf("Dyn record is waiting on a publish: %s", err)
+		}
+		if err != nil {
+			log.Printf("[WARN] Pending Dyn job %d did not succeed, the change will be planned again: %s", jobID, err)
+		}
+		d.Set("pending_job_id", 0)
+	}
+
+	// keep a change staged on a zone published manually in state until it
+	// is published or discarded
+	if d.Get("pending_publish").(bool) {
+		pending, err := client.HasPendingChange(ctx, record.Zone, record.FQDN, record.Type)
+		if err != nil {
+			return fmt.Errorf("Failed to list pending changes of Dyn zone %s: %s", record.Zone, err)
+		}
+		if pending {
+			log.Printf("[INFO] Dyn record %s %s is waiting for zone %s to be published", record.Type, record.FQDN, record.Zone)
+			return nil
+		}
+		d.Set("pending_publish", false)
+
+		if record.ID == unpublishedRecordID {
+			if err := client.GetRecordID(ctx, record); err != nil {
+				log.Printf("[WARN] Dyn record %s %s was discarded before being published: %s", record.Type, record.FQDN, err)
+				d.SetId("")
+				return nil
+			}
+			d.SetId(record.ID)
+		}
+	}
+
+	err := client.GetRecord(ctx, record)
+	if isNotFound(err) {
+		// deleted outside of Terraform, or a create whose publish failed
+		log.Printf("[WARN] Dyn record %s %s is gone, removing it from state", record.Type, record.FQDN)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("Couldn't find Dyn record: %s", err)
 	}
@@ -147,8 +482,8 @@ func resourceDynRecordRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("fqdn", record.FQDN)
 	d.Set("name", record.Name)
 	d.Set("type", record.Type)
-	d.Set("ttl", record.TTL)
-	d.Set("value", record.Value)
+	d.Set("ttl", stateTTL(record.TTL))
+	d.Set("value", normalizeRecordValue(record.Type, record.Value))
 
 	return nil
 }
@@ -156,34 +491,67 @@ func resourceDynRecordRead(d *schema.ResourceData, meta interface{}) error {
 func resourceDynRecordUpdate(d *schema.ResourceData, meta interface{}) error {
 	mutex.Lock()
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 
 	record := &dynect.Record{
 		ID:    d.Id(),
 		Name:  d.Get("name").(string),
 		Zone:  d.Get("zone").(string),
-		TTL:   d.Get("ttl").(string),
+		FQDN:  d.Get("fqdn").(string),
+		TTL:   recordTTL(d.Get("ttl").(int)),
 		Type:  d.Get("type").(string),
 		Value: d.Get("value").(string),
 	}
 	log.Printf("[DEBUG] Dyn record update configuration: %#v", record)
 
-	// update the record
-	err := client.UpdateRecord(record)
+	// publish_notes only applies to later publishes
+	if !d.HasChange("name") && !d.HasChange("type") && !d.HasChange("value") && !d.HasChange("ttl") {
+		mutex.Unlock()
+		return resourceDynRecordRead(d, meta)
+	}
+
+	if err := resourceDynRecordUnpublished(d); err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	if err := resourceDynRecordDefaultTTL(ctx, client, record); err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	notes, err := resourceDynRecordNotes(d, client, record)
 	if err != nil {
 		mutex.Unlock()
-		return fmt.Errorf("Failed to update Dyn record: %s", err)
+		return err
 	}
 
-	// publish the zone
-	err = client.PublishZone(record.Zone)
+	// update the record and publish the zone
+	err = publishZoneChanges(ctx, client, record.Zone, notes, func() error {
+		if d.HasChange("name") || d.HasChange("type") {
+			// replace the record within the same publish
+			return resourceDynRecordReplace(ctx, d, client, record)
+		}
+		if err := client.UpdateRecord(ctx, record); err != nil {
+			return fmt.Errorf("Failed to update Dyn record: %s", err)
+		}
+		return nil
+	})
 	if err != nil {
 		mutex.Unlock()
-		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+		return resourceDynRecordJobError(d, err)
+	}
+
+	if !client.autoPublishes(record.Zone) {
+		resourceDynRecordStaged(ctx, d, client, record)
+		mutex.Unlock()
+		return nil
 	}
 
 	// get the record ID
-	err = client.GetRecordID(record)
+	err = client.GetRecordID(ctx, record)
 	if err != nil {
 		mutex.Unlock()
 		return fmt.Errorf("%s", err)
@@ -194,11 +562,59 @@ func resourceDynRecordUpdate(d *schema.ResourceData, meta interface{}) error {
 	return resourceDynRecordRead(d, meta)
 }
 
+// resourceDynRecordReplace stages the creation of record under its new name
+// or type and the deletion of the record it replaces, so that a single
+// publish makes both changes at once and the name never stops resolving.
+func resourceDynRecordReplace(ctx context.Context, d *schema.ResourceData, client *Client, record *dynect.Record) error {
+	oldName, _ := d.GetChange("name")
+	oldType, _ := d.GetChange("type")
+
+	old := &dynect.Record{
+		ID:   d.Id(),
+		Zone: record.Zone,
+		FQDN: recordFQDN(oldName.(string), record.Zone),
+		Type: oldType.(string),
+	}
+	record.ID = ""
+	record.FQDN = recordFQDN(record.Name, record.Zone)
+
+	log.Printf("[INFO] Replacing Dyn record %s %s with %s %s", old.Type, old.FQDN, record.Type, record.FQDN)
+
+	create := func() error {
+		if err := client.CreateRecord(ctx, record); err != nil {
+			return fmt.Errorf("Failed to create Dyn record: %s", err)
+		}
+		return nil
+	}
+	remove := func() error {
+		if err := client.DeleteRecord(ctx, old); err != nil {
+			return fmt.Errorf("Failed to delete Dyn record: %s", err)
+		}
+		return nil
+	}
+
+	// A record changing type at the same node is removed first, so the two
+	// types never conflict; a renamed record is only removed once its
+	// replacement exists.
+	steps := []func() error{create, remove}
+	if strings.EqualFold(old.FQDN, record.FQDN) {
+		steps = []func() error{remove, create}
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func resourceDynRecordDelete(d *schema.ResourceData, meta interface{}) error {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	client := meta.(*dynect.ConvenientClient)
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	record := &dynect.Record{
 		ID:   d.Id(),
@@ -210,17 +626,62 @@ func resourceDynRecordDelete(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[INFO] Deleting Dyn record: %s, %s", record.FQDN, record.ID)
 
-	// delete the record
-	err := client.DeleteRecord(record)
+	if err := resourceDynRecordUnpublished(d); err != nil {
+		return err
+	}
+
+	notes, err := resourceDynRecordNotes(d, client, record)
 	if err != nil {
-		return fmt.Errorf("Failed to delete Dyn record: %s", err)
+		return err
 	}
 
-	// publish the zone
-	err = client.PublishZone(record.Zone)
+	// delete the record and publish the zone
+	err = publishZoneChanges(ctx, client, record.Zone, notes, func() error {
+		if err := client.DeleteRecord(ctx, record); err != nil {
+			return fmt.Errorf("Failed to delete Dyn record: %s", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Failed to publish Dyn zone: %s", err)
+		return resourceDynRecordJobError(d, err)
 	}
 
 	return nil
 }
+
+// resourceDynRecordNotes renders the note of the publish that changes record.
+func resourceDynRecordNotes(d *schema.ResourceData, client *Client, record *dynect.Record) (string, error) {
+	resource := fmt.Sprintf("dyn_record %s %s", record.Type, recordFQDN(record.Name, record.Zone))
+	return renderPublishNotes(client, d.Get("publish_notes").(string), resource, record.Zone)
+}
+
+// recordTTL converts a TTL from the configuration for the Dyn API, where an
+// empty TTL inherits the zone default.
+func recordTTL(ttl int) string {
+	if ttl == 0 {
+		return ""
+	}
+	return strconv.Itoa(ttl)
+}
+
+// stateTTL converts a TTL read from the Dyn API for the state.
+func stateTTL(ttl string) int {
+	v, err := strconv.Atoi(ttl)
+	if err != nil {
+		log.Printf("[WARN] Dyn returned an invalid TTL %q", ttl)
+		return 0
+	}
+	return v
+}
+
+// resourceDynRecordJobError records the job of a publish that is still
+// running, so that the next refresh waits on it instead of the change being
+// submitted again.
+func resourceDynRecordJobError(d *schema.ResourceData, err error) error {
+	if jobID := pendingJobID(err); jobID != 0 {
+		log.Printf("[WARN] Dyn publish job %d for %s is still running", jobID, d.Get("zone").(string))
+		d.Set("pending_job_id", jobID)
+	}
+
+	return err
+}
@@ -0,0 +1,78 @@
+package dyn
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceDynRecordV0 is the schema of dyn_record before ttl became a number.
+func resourceDynRecordV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ttl": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"pending_job_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceDynRecordStateUpgradeV0 converts ttl from a string to a number. An
+// empty TTL inherits the zone default and becomes 0.
+func resourceDynRecordStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	switch ttl := rawState["ttl"].(type) {
+	case nil:
+		rawState["ttl"] = 0
+	case string:
+		if ttl == "" {
+			rawState["ttl"] = 0
+			break
+		}
+		v, err := strconv.Atoi(ttl)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to migrate the TTL %q of Dyn record %v: %s", ttl, rawState["id"], err)
+		}
+		rawState["ttl"] = v
+	}
+
+	log.Printf("[DEBUG] Migrated Dyn record %v to schema version 1, ttl: %v", rawState["id"], rawState["ttl"])
+
+	return rawState, nil
+}
@@ -0,0 +1,56 @@
+package dyn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceDynRecordStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		ttl      interface{}
+		expected interface{}
+	}{
+		{"3600", 3600},
+		{"30", 30},
+		{"", 0},
+		{nil, 0},
+	}
+
+	for _, tc := range cases {
+		rawState := map[string]interface{}{
+			"id":    "12345",
+			"zone":  "terraform.io",
+			"name":  "www",
+			"type":  "A",
+			"value": "192.168.0.10",
+			"ttl":   tc.ttl,
+		}
+		expected := map[string]interface{}{
+			"id":    "12345",
+			"zone":  "terraform.io",
+			"name":  "www",
+			"type":  "A",
+			"value": "192.168.0.10",
+			"ttl":   tc.expected,
+		}
+
+		actual, err := resourceDynRecordStateUpgradeV0(rawState, nil)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("ttl %#v: expected %#v, got %#v", tc.ttl, expected, actual)
+		}
+	}
+}
+
+func TestResourceDynRecordStateUpgradeV0_invalid(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":  "12345",
+		"ttl": "1h",
+	}
+
+	if _, err := resourceDynRecordStateUpgradeV0(rawState, nil); err == nil {
+		t.Fatal("expected an error for a TTL that is not a number")
+	}
+}
@@ -1,12 +1,17 @@
 package dyn
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/nesv/go-dynect/dynect"
 )
@@ -235,8 +240,186 @@ func TestAccDynRecord_MX_record(t *testing.T) {
 	})
 }
 
+func TestAccDynRecord_AAAA_expanded(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_AAAA_expanded, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "ipv6"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "type", "AAAA"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "value", "2001:db8::10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDynRecord_rename(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_basic, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "terraform"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "fqdn", "terraform."+zone),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_renamed, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					testAccCheckDynRecordAttributes(&record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "name", "terraform-renamed"),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "fqdn", "terraform-renamed."+zone),
+				),
+			},
+		},
+	})
+}
+
+func TestRecordFQDN(t *testing.T) {
+	cases := []struct {
+		name     string
+		zone     string
+		expected string
+	}{
+		{"", "terraform.io", "terraform.io"},
+		{"terraform.io", "terraform.io", "terraform.io"},
+		{"www", "terraform.io", "www.terraform.io"},
+		{"www", "terraform.io.", "www.terraform.io"},
+	}
+
+	for _, tc := range cases {
+		if actual := recordFQDN(tc.name, tc.zone); actual != tc.expected {
+			t.Errorf("recordFQDN(%q, %q): expected %q, got %q", tc.name, tc.zone, tc.expected, actual)
+		}
+	}
+}
+
+func TestResourceDynRecordRead_pendingJobFailed(t *testing.T) {
+	// the publish of the record failed after the apply timed out, so the
+	// record was never created
+	mux := http.NewServeMux()
+	mux.HandleFunc("/REST/Job/1234", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "failure", "job_id": 1234, "msgs": [{"INFO": "publish failed", "LVL": "ERROR"}]}`)
+	})
+	mux.HandleFunc("/REST/ARecord/example.com/www.example.com/42", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"status": "failure", "msgs": [{"INFO": "node: Not in zone", "LVL": "ERROR"}]}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceDynRecord().Schema, map[string]interface{}{
+		"zone":  "example.com",
+		"name":  "www",
+		"type":  "A",
+		"value": "192.168.0.10",
+	})
+	d.SetId("42")
+	d.Set("fqdn", "www.example.com")
+	d.Set("pending_job_id", 1234)
+
+	if err := resourceDynRecordRead(d, testClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the record to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestResourceDynRecordDefaultTTL(t *testing.T) {
+	var body string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/REST/SOARecord/example.com/example.com/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "success", "data": [{"zone": "example.com", "fqdn": "example.com", "record_type": "SOA", "record_id": 1, "ttl": 1800, "rdata": {}}]}`)
+	})
+	mux.HandleFunc("/REST/ARecord/example.com/www.example.com/42", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		fmt.Fprint(w, `{"status": "success", "data": {}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := testClient(server)
+	record := &dynect.Record{
+		ID:    "42",
+		Zone:  "example.com",
+		FQDN:  "www.example.com",
+		Type:  "A",
+		TTL:   recordTTL(0),
+		Value: "192.168.0.10",
+	}
+	if err := resourceDynRecordDefaultTTL(context.Background(), client, record); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := client.UpdateRecord(context.Background(), record); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if expected := `{"rdata":{"address":"192.168.0.10"},"ttl":"1800"}`; body != expected {
+		t.Fatalf("expected request body %s, got %s", expected, body)
+	}
+}
+
+func TestCompatibleRecordTypes(t *testing.T) {
+	compatible := [][2]string{{"A", "ALIAS"}, {"ALIAS", "A"}, {"AAAA", "ALIAS"}, {"A", "AAAA"}}
+	for _, c := range compatible {
+		if !compatibleRecordTypes(c[0], c[1]) {
+			t.Errorf("%s should be replaceable by %s in place", c[0], c[1])
+		}
+	}
+
+	incompatible := [][2]string{{"A", "CNAME"}, {"CNAME", "ALIAS"}, {"MX", "NS"}, {"TXT", "SPF"}}
+	for _, c := range incompatible {
+		if compatibleRecordTypes(c[0], c[1]) {
+			t.Errorf("%s should not be replaceable by %s in place", c[0], c[1])
+		}
+	}
+}
+
+func TestAccDynRecord_invalidValue(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccCheckDynRecordConfig_IPv6_in_A, zone),
+				ExpectError: regexp.MustCompile("not a valid IPv4 address"),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckDynRecordConfig_CNAME_apex, zone),
+				ExpectError: regexp.MustCompile("cannot be created at the apex"),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckDynRecordConfig_CNAME_conflict, zone, zone),
+				ExpectError: regexp.MustCompile("cannot have a"),
+			},
+		},
+	})
+}
+
 func testAccCheckDynRecordDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*dynect.ConvenientClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "dyn_record" {
@@ -250,7 +433,7 @@ func testAccCheckDynRecordDestroy(s *terraform.State) error {
 			Type: rs.Primary.Attributes["type"],
 		}
 
-		err := client.GetRecord(foundRecord)
+		err := client.GetRecord(context.Background(), foundRecord)
 
 		if err != nil {
 			return fmt.Errorf("Record still exists")
@@ -294,7 +477,7 @@ func testAccCheckDynRecordExists(n string, record *dynect.Record) resource.TestC
 			return fmt.Errorf("No Record ID is set")
 		}
 
-		client := testAccProvider.Meta().(*dynect.ConvenientClient)
+		client := testAccProvider.Meta().(*Client)
 
 		foundRecord := &dynect.Record{
 			Zone: rs.Primary.Attributes["zone"],
@@ -303,7 +486,7 @@ func testAccCheckDynRecordExists(n string, record *dynect.Record) resource.TestC
 			Type: rs.Primary.Attributes["type"],
 		}
 
-		err := client.GetRecord(foundRecord)
+		err := client.GetRecord(context.Background(), foundRecord)
 
 		if err != nil {
 			return err
@@ -402,3 +585,53 @@ resource "dyn_record" "foobar" {
   type  = "MX"
   ttl   = 30
 }`
+
+const testAccCheckDynRecordConfig_IPv6_in_A = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "terraform"
+  value = "2001:db8::10"
+  type  = "A"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_CNAME_apex = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  value = "something.terraform.io"
+  type  = "CNAME"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_CNAME_conflict = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "terraform-conflict"
+  value = "192.168.0.10"
+  type  = "A"
+}
+
+resource "dyn_record" "conflict" {
+  zone  = "%s"
+  name  = "terraform-conflict"
+  value = "something.terraform.io"
+  type  = "CNAME"
+}`
+
+const testAccCheckDynRecordConfig_AAAA_expanded = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "IPv6"
+  value = "2001:0DB8:0000:0000:0000:0000:0000:0010"
+  type  = "AAAA"
+  ttl   = 3600
+}`
+
+const testAccCheckDynRecordConfig_renamed = `
+resource "dyn_record" "foobar" {
+  zone  = "%s"
+  name  = "terraform-renamed"
+  value = "192.168.0.10"
+  type  = "A"
+  ttl   = 3600
+}`
@@ -0,0 +1,261 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynReverseDNS() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynReverseDNSCreate,
+		Read:   resourceDynReverseDNSRead,
+		Update: resourceDynReverseDNSUpdate,
+		Delete: resourceDynReverseDNSDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynServiceImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRecordName,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hosts": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateRecordName,
+				},
+				Set: schema.HashString,
+			},
+
+			"record_types": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateStringInSlice([]string{"A", "AAAA"}),
+				},
+				Set: schema.HashString,
+			},
+
+			"netmask": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDR,
+			},
+
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateTTL,
+			},
+
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"service_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"publish": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+		},
+	}
+}
+
+// resourceDynReverseDNSRequest builds the service from the configuration.
+func resourceDynReverseDNSRequest(d *schema.ResourceData) *ReverseDNS {
+	return &ReverseDNS{
+		Hosts:       sortedStringSet(d.Get("hosts").(*schema.Set)),
+		RecordTypes: sortedStringSet(d.Get("record_types").(*schema.Set)),
+		Netmask:     d.Get("netmask").(string),
+		TTL:         looseString(strconv.Itoa(d.Get("ttl").(int))),
+		Active:      yesNo(d.Get("active").(bool)),
+		Publish:     "N",
+	}
+}
+
+// resourceDynReverseDNSNotes renders the note attached to the publishes of
+// the service at fqdn.
+func resourceDynReverseDNSNotes(d *schema.ResourceData, client *Client, zone, fqdn string) (string, error) {
+	return renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_reverse_dns "+fqdn, zone)
+}
+
+func resourceDynReverseDNSCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	fqdn := recordFQDN(d.Get("name").(string), zone)
+	notes, err := resourceDynReverseDNSNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	log.Printf("[INFO] Creating Dyn Reverse DNS service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		var err error
+		if id, err = client.CreateReverseDNS(ctx, zone, fqdn, resourceDynReverseDNSRequest(d)); err != nil {
+			return fmt.Errorf("Failed to create Dyn Reverse DNS service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(fqdn)
+	d.Set("service_id", id)
+
+	return resourceDynReverseDNSReadContext(ctx, d, client)
+}
+
+func resourceDynReverseDNSRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynReverseDNSReadContext(ctx, d, client)
+}
+
+// resourceDynReverseDNSReadContext reads the service. Imported services are
+// looked up by node first, which must have no other Reverse DNS service.
+func resourceDynReverseDNSReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone, fqdn, id := d.Get("zone").(string), d.Id(), d.Get("service_id").(string)
+	var err error
+	if id == "" {
+		id, err = client.GetReverseDNSID(ctx, zone, fqdn)
+	}
+	var reverse *ReverseDNS
+	if err == nil {
+		reverse, err = client.GetReverseDNS(ctx, zone, fqdn, id)
+	}
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn Reverse DNS service %s was deleted outside of Terraform", fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn Reverse DNS service %s: %s", fqdn, err)
+	}
+
+	hosts := make([]string, len(reverse.Hosts))
+	for i, host := range reverse.Hosts {
+		hosts[i] = strings.TrimSuffix(host, ".")
+	}
+
+	d.Set("fqdn", fqdn)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("service_id", id)
+	d.Set("hosts", hosts)
+	d.Set("record_types", reverse.RecordTypes)
+	d.Set("netmask", reverse.Netmask)
+	d.Set("ttl", looseInt(reverse.TTL))
+	d.Set("active", reverse.Active != "N")
+	return nil
+}
+
+func resourceDynReverseDNSUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	zone, fqdn, id := d.Get("zone").(string), d.Id(), d.Get("service_id").(string)
+	if !d.HasChange("hosts") && !d.HasChange("record_types") && !d.HasChange("netmask") &&
+		!d.HasChange("ttl") && !d.HasChange("active") {
+		// publish and publish_notes only apply to later changes
+		return resourceDynReverseDNSReadContext(ctx, d, client)
+	}
+
+	notes, err := resourceDynReverseDNSNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating Dyn Reverse DNS service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.UpdateReverseDNS(ctx, zone, fqdn, id, resourceDynReverseDNSRequest(d)); err != nil {
+			return fmt.Errorf("Failed to update Dyn Reverse DNS service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceDynReverseDNSReadContext(ctx, d, client)
+}
+
+func resourceDynReverseDNSDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone, fqdn, id := d.Get("zone").(string), d.Id(), d.Get("service_id").(string)
+	notes, err := resourceDynReverseDNSNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Dyn Reverse DNS service %s", fqdn)
+	return stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.DeleteReverseDNS(ctx, zone, fqdn, id); err != nil {
+			return fmt.Errorf("Failed to delete Dyn Reverse DNS service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+}
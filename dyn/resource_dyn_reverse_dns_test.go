@@ -0,0 +1,77 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynReverseDNS_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+	reverseZone := os.Getenv("DYN_REVERSE_ZONE")
+	if reverseZone == "" {
+		t.Skip("DYN_REVERSE_ZONE must be set to an IPv6 reverse zone such as 8.b.d.0.1.0.0.2.ip6.arpa for this acceptance test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynReverseDNSDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynReverseDNSConfig, reverseZone, zone, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_reverse_dns.foobar", "fqdn", reverseZone),
+					resource.TestCheckResourceAttr("dyn_reverse_dns.foobar", "hosts.#", "1"),
+					resource.TestCheckResourceAttrSet("dyn_reverse_dns.foobar", "service_id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynReverseDNSConfig, reverseZone, zone, 600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_reverse_dns.foobar", "ttl", "600"),
+				),
+			},
+			{
+				ResourceName:            "dyn_reverse_dns.foobar",
+				ImportState:             true,
+				ImportStateId:           reverseZone + "/" + reverseZone,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"publish"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynReverseDNSDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_reverse_dns" {
+			continue
+		}
+
+		_, err := client.GetReverseDNS(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.ID, rs.Primary.Attributes["service_id"])
+		if err == nil {
+			return fmt.Errorf("Reverse DNS service still exists")
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynReverseDNSConfig = `
+resource "dyn_reverse_dns" "foobar" {
+	zone = "%s"
+	hosts = ["terraform-reverse.%s"]
+	record_types = ["AAAA"]
+	netmask = "2001:db8::/32"
+	ttl = %d
+}`
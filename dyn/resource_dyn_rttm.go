@@ -0,0 +1,432 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynRTTM() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynRTTMCreate,
+		Read:   resourceDynRTTMRead,
+		Update: resourceDynRTTMUpdate,
+		Delete: resourceDynRTTMDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynServiceImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRecordName,
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"region": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region_code": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringInSlice(gslbRegionCodes),
+						},
+						"autopopulate": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"serve_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validateIntAtLeast(1),
+						},
+						"failover_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "global",
+							ValidateFunc: validateStringInSlice([]string{"ip", "cname", "region", "global"}),
+						},
+						"failover_data": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"pool": gslbPoolSchema(),
+					},
+				},
+			},
+
+			"monitor": monitorSchema(),
+
+			"performance_monitor": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringInSlice([]string{"HTTP", "HTTPS", "PING", "SMTP", "TCP"}),
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validateIntInSlice([]int{10, 20, 30, 60}),
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateIntBetween(1, 65535),
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"header": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"contact_nickname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validateIntBetween(1, maxTTL),
+			},
+
+			"auto_recover": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"notify_events": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateStringInSlice([]string{"ip", "svc", "nosrv"}),
+				},
+				Set: schema.HashString,
+			},
+
+			"syslog_server": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"syslog_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      514,
+				ValidateFunc: validateIntBetween(1, 65535),
+			},
+
+			"syslog_ident": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "dynect",
+			},
+
+			"syslog_facility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "daemon",
+				ValidateFunc: validateStringInSlice(syslogFacilities),
+			},
+
+			"syslog_delivery": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "change",
+				ValidateFunc: validateStringInSlice([]string{"all", "change"}),
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"publish": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+		},
+	}
+}
+
+// resourceDynRTTMRequest builds the service from the configuration.
+func resourceDynRTTMRequest(d *schema.ResourceData) *RTTM {
+	rttm := &RTTM{
+		ContactNickname:    d.Get("contact_nickname").(string),
+		TTL:                looseString(strconv.Itoa(d.Get("ttl").(int))),
+		AutoRecover:        yesNo(d.Get("auto_recover").(bool)),
+		NotifyEvents:       joinStringSet(d.Get("notify_events").(*schema.Set)),
+		Monitor:            expandMonitor(d.Get("monitor")),
+		PerformanceMonitor: expandPerformanceMonitor(d.Get("performance_monitor")),
+		Publish:            "N",
+	}
+	if server := d.Get("syslog_server").(string); server != "" {
+		rttm.SyslogServer = server
+		rttm.SyslogPort = looseString(strconv.Itoa(d.Get("syslog_port").(int)))
+		rttm.SyslogIdent = d.Get("syslog_ident").(string)
+		rttm.SyslogFacility = d.Get("syslog_facility").(string)
+		rttm.SyslogDelivery = d.Get("syslog_delivery").(string)
+	}
+
+	for _, v := range d.Get("region").([]interface{}) {
+		m := v.(map[string]interface{})
+		rttm.Region = append(rttm.Region, RTTMRegion{
+			RegionCode:   m["region_code"].(string),
+			Autopopulate: yesNo(m["autopopulate"].(bool)),
+			ServeCount:   looseString(strconv.Itoa(m["serve_count"].(int))),
+			FailoverMode: m["failover_mode"].(string),
+			FailoverData: m["failover_data"].(string),
+			Pool:         expandGSLBPool(m["pool"]),
+		})
+	}
+	return rttm
+}
+
+// expandPerformanceMonitor builds the check that measures the response time
+// of the addresses from its performance_monitor block.
+func expandPerformanceMonitor(v interface{}) ServiceMonitor {
+	m := v.([]interface{})[0].(map[string]interface{})
+	monitor := ServiceMonitor{
+		Protocol: m["protocol"].(string),
+		Interval: looseString(strconv.Itoa(m["interval"].(int))),
+		Path:     m["path"].(string),
+		Host:     m["host"].(string),
+		Header:   m["header"].(string),
+	}
+	if port := m["port"].(int); port > 0 {
+		monitor.Port = looseString(strconv.Itoa(port))
+	}
+	return monitor
+}
+
+// flattenPerformanceMonitor renders the check that measures the response
+// time of the addresses as a performance_monitor block.
+func flattenPerformanceMonitor(monitor ServiceMonitor) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"protocol": monitor.Protocol,
+		"interval": looseInt(monitor.Interval),
+		"port":     looseInt(monitor.Port),
+		"path":     monitor.Path,
+		"host":     monitor.Host,
+		"header":   monitor.Header,
+	}}
+}
+
+// resourceDynRTTMNotes renders the note attached to the publishes of the
+// service at fqdn.
+func resourceDynRTTMNotes(d *schema.ResourceData, client *Client, zone, fqdn string) (string, error) {
+	return renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_rttm "+fqdn, zone)
+}
+
+func resourceDynRTTMCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	fqdn := recordFQDN(d.Get("name").(string), zone)
+	notes, err := resourceDynRTTMNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating Dyn RTTM service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.CreateRTTM(ctx, zone, fqdn, resourceDynRTTMRequest(d)); err != nil {
+			return fmt.Errorf("Failed to create Dyn RTTM service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(fqdn)
+
+	return resourceDynRTTMReadContext(ctx, d, client)
+}
+
+func resourceDynRTTMRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynRTTMReadContext(ctx, d, client)
+}
+
+func resourceDynRTTMReadContext(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	rttm, err := client.GetRTTM(ctx, zone, fqdn)
+	if isNotFound(err) {
+		log.Printf("[WARN] Dyn RTTM service %s was deleted outside of Terraform", fqdn)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read Dyn RTTM service %s: %s", fqdn, err)
+	}
+
+	d.Set("fqdn", fqdn)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("status", rttm.Status)
+	d.Set("contact_nickname", rttm.ContactNickname)
+	d.Set("ttl", looseInt(rttm.TTL))
+	d.Set("auto_recover", rttm.AutoRecover != "N")
+	d.Set("notify_events", splitStringSet(rttm.NotifyEvents))
+	if rttm.SyslogServer != "" {
+		d.Set("syslog_server", rttm.SyslogServer)
+		d.Set("syslog_port", looseInt(rttm.SyslogPort))
+		d.Set("syslog_ident", rttm.SyslogIdent)
+		d.Set("syslog_facility", rttm.SyslogFacility)
+		d.Set("syslog_delivery", rttm.SyslogDelivery)
+	} else {
+		d.Set("syslog_server", "")
+	}
+	if err := d.Set("monitor", flattenMonitor(rttm.Monitor)); err != nil {
+		return err
+	}
+	if err := d.Set("performance_monitor", flattenPerformanceMonitor(rttm.PerformanceMonitor)); err != nil {
+		return err
+	}
+	return d.Set("region", flattenRTTMRegions(rttm.Region, d.Get("region").([]interface{})))
+}
+
+// flattenRTTMRegions renders the regions of a service in the order they are
+// configured, like flattenGSLBRegions.
+func flattenRTTMRegions(regions []RTTMRegion, configured []interface{}) []map[string]interface{} {
+	order := configuredOrder(configured, "region_code")
+	pools := configuredPools(configured)
+	sorted := make([]RTTMRegion, len(regions))
+	copy(sorted, regions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order.less(sorted[i].RegionCode, sorted[j].RegionCode)
+	})
+
+	flattened := make([]map[string]interface{}, len(sorted))
+	for i, r := range sorted {
+		flattened[i] = map[string]interface{}{
+			"region_code":   r.RegionCode,
+			"autopopulate":  r.Autopopulate == "Y",
+			"serve_count":   looseInt(r.ServeCount),
+			"failover_mode": r.FailoverMode,
+			"failover_data": r.FailoverData,
+			"pool":          flattenGSLBPool(r.Pool, pools[r.RegionCode]),
+		}
+	}
+	return flattened
+}
+
+func resourceDynRTTMUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	if !d.HasChange("region") && !d.HasChange("monitor") && !d.HasChange("performance_monitor") &&
+		!d.HasChange("contact_nickname") && !d.HasChange("ttl") && !d.HasChange("auto_recover") &&
+		!d.HasChange("notify_events") && !d.HasChange("syslog_server") && !d.HasChange("syslog_port") &&
+		!d.HasChange("syslog_ident") && !d.HasChange("syslog_facility") && !d.HasChange("syslog_delivery") {
+		// publish and publish_notes only apply to later changes
+		return resourceDynRTTMReadContext(ctx, d, client)
+	}
+
+	notes, err := resourceDynRTTMNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating Dyn RTTM service %s", fqdn)
+	err = stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.UpdateRTTM(ctx, zone, fqdn, resourceDynRTTMRequest(d)); err != nil {
+			return fmt.Errorf("Failed to update Dyn RTTM service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceDynRTTMReadContext(ctx, d, client)
+}
+
+func resourceDynRTTMDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	zone, fqdn := d.Get("zone").(string), d.Id()
+	notes, err := resourceDynRTTMNotes(d, client, zone, fqdn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting Dyn RTTM service %s", fqdn)
+	return stageServiceChanges(ctx, client, zone, notes, d.Get("publish").(bool), func() error {
+		if err := client.DeleteRTTM(ctx, zone, fqdn); err != nil {
+			return fmt.Errorf("Failed to delete Dyn RTTM service %s: %s", fqdn, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynRTTM_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+	contact := testAccContactNickname(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRTTMDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynRTTMConfig, zone, contact, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_rttm.foobar", "fqdn", "terraform-rttm."+zone),
+					resource.TestCheckResourceAttr("dyn_rttm.foobar", "region.0.pool.#", "2"),
+					resource.TestCheckResourceAttr("dyn_rttm.foobar", "performance_monitor.0.interval", "10"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynRTTMConfig, zone, contact, 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_rttm.foobar", "performance_monitor.0.interval", "30"),
+				),
+			},
+			{
+				ResourceName:            "dyn_rttm.foobar",
+				ImportState:             true,
+				ImportStateId:           zone + "/terraform-rttm." + zone,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"publish"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynRTTMDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_rttm" {
+			continue
+		}
+
+		_, err := client.GetRTTM(context.Background(), rs.Primary.Attributes["zone"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("RTTM service still exists")
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynRTTMConfig = `
+resource "dyn_rttm" "foobar" {
+	zone = "%s"
+	name = "terraform-rttm"
+	contact_nickname = "%s"
+
+	region {
+		region_code = "global"
+
+		pool {
+			address = "192.168.0.10"
+		}
+
+		pool {
+			address = "192.168.0.11"
+		}
+	}
+
+	monitor {
+		protocol = "HTTP"
+		path = "/"
+	}
+
+	performance_monitor {
+		protocol = "HTTP"
+		interval = %d
+		path = "/"
+	}
+}`
@@ -0,0 +1,317 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func resourceDynZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynZoneFileCreate,
+		Read:   resourceDynZoneFileRead,
+		Update: resourceDynZoneFileUpdate,
+		Delete: resourceDynZoneFileDelete,
+
+		CustomizeDiff: resourceDynZoneFileCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"zone_file": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+
+			"delete_zone": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"records_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"pending_job_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"pending_publish": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceDynZoneFileCustomizeDiff checks the zone file, and plans a change
+// of records_sha256 when the live records have drifted from it.
+func resourceDynZoneFileCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("zone") || !d.NewValueKnown("zone_file") {
+		return d.SetNewComputed("records_sha256")
+	}
+
+	zone := d.Get("zone").(string)
+	records, err := parseZoneFile(d.Get("zone_file").(string), zone)
+	if err != nil {
+		return fmt.Errorf("invalid zone file for %s: %s", zone, err)
+	}
+	for _, r := range zoneFileRecords(records) {
+		if err := checkRecordValue(r.Type, r.Value); err != nil {
+			return fmt.Errorf("invalid zone file for %s: invalid value for %s record %s: %s", zone, r.Type, r.FQDN, err)
+		}
+	}
+
+	// the records of services are left out as on refresh, so that they do
+	// not show up as drift
+	if client, ok := meta.(*Client); ok {
+		nodes, err := client.GetServiceNodes(client.StopContext(), zone)
+		if err != nil {
+			return err
+		}
+		records = withoutServiceRecords(records, nodes)
+	}
+
+	if digest := zoneRecordsSHA256(records); digest != d.Get("records_sha256").(string) {
+		return d.SetNew("records_sha256", digest)
+	}
+	return nil
+}
+
+func resourceDynZoneFileCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	notes, err := renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_zone_file "+zone, zone)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Uploading the zone file of Dyn zone %s", zone)
+	uploaded := false
+	err = publishZoneChanges(ctx, client, zone, notes, func() error {
+		if err := client.UploadZoneFile(ctx, zone, d.Get("zone_file").(string)); err != nil {
+			return fmt.Errorf("Failed to upload the zone file of Dyn zone %s: %s", zone, err)
+		}
+		uploaded = true
+		return nil
+	})
+	if jobID := pendingJobID(err); jobID != 0 {
+		// the zone exists, Read waits for the publish to complete
+		log.Printf("[WARN] Dyn publish job %d for %s is still running", jobID, zone)
+		d.SetId(zone)
+		d.Set("pending_job_id", jobID)
+		return nil
+	}
+	if err != nil {
+		if uploaded {
+			// the zone was created by the upload, and would make the next
+			// attempt fail
+			resourceDynZoneFileDeleteStaged(client, zone)
+		}
+		return err
+	}
+	d.SetId(zone)
+
+	if !client.autoPublishes(zone) {
+		resourceDynZoneFileStaged(d)
+		return nil
+	}
+	return resourceDynZoneFileReadLocked(ctx, d, client)
+}
+
+// resourceDynZoneFileDeleteStaged deletes a zone created from a zone file
+// that could not be published. It runs after the create timeout may have
+// expired, so it gets a deadline of its own.
+func resourceDynZoneFileDeleteStaged(client *Client, zone string) {
+	ctx, cancel := context.WithTimeout(context.Background(), discardTimeout)
+	defer cancel()
+
+	log.Printf("[INFO] Deleting Dyn zone %s, which could not be published", zone)
+	if err := client.DeleteZone(ctx, zone); err != nil {
+		log.Printf("[WARN] Failed to delete Dyn zone %s, delete it before trying again: %s", zone, err)
+	}
+}
+
+func resourceDynZoneFileRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	return resourceDynZoneFileReadLocked(ctx, d, client)
+}
+
+// resourceDynZoneFileReadLocked records the digest of the live records of
+// the zone, and logs how they differ from the zone file.
+func resourceDynZoneFileReadLocked(ctx context.Context, d *schema.ResourceData, client *Client) error {
+	zone := d.Id()
+	d.Set("zone", zone)
+
+	// resume waiting on a publish that was still running at the end of the
+	// last run
+	if jobID := d.Get("pending_job_id").(int); jobID != 0 {
+		log.Printf("[INFO] Waiting for pending Dyn job %d", jobID)
+		err := client.WaitJob(ctx, jobID, nil)
+		if pendingJobID(err) != 0 {
+			return fmt.Errorf("Dyn zone file is waiting on a publish: %s", err)
+		}
+		if err != nil {
+			log.Printf("[WARN] Pending Dyn job %d did not succeed, the changes will be planned again: %s", jobID, err)
+		}
+		d.Set("pending_job_id", 0)
+	}
+
+	// keep changes staged on a zone published manually in state until they
+	// are published or discarded
+	if d.Get("pending_publish").(bool) {
+		changes, err := client.GetZoneChanges(ctx, zone)
+		if err != nil {
+			return fmt.Errorf("Failed to list pending changes of Dyn zone %s: %s", zone, err)
+		}
+		if len(changes) > 0 {
+			log.Printf("[INFO] Dyn zone %s has %d changes waiting to be published", zone, len(changes))
+			return nil
+		}
+		d.Set("pending_publish", false)
+	}
+
+	live, nodes, err := resourceDynZoneFileLive(ctx, client, zone)
+	if err != nil {
+		return err
+	}
+
+	if desired, err := parseZoneFile(d.Get("zone_file").(string), zone); err == nil {
+		for _, c := range diffRecordSets(live, withoutServiceRecords(zoneFileRecords(desired), nodes)) {
+			log.Printf("[WARN] Dyn %s records of %s differ from the zone file of %s", c.Key.Type, recordFQDN(c.Key.Name, zone), zone)
+		}
+	}
+
+	d.Set("records_sha256", zoneRecordsSHA256(live))
+	return nil
+}
+
+// resourceDynZoneFileLive lists the live records of the zone compared with
+// the zone file, leaving out those belonging to services, and returns the
+// nodes of the services.
+func resourceDynZoneFileLive(ctx context.Context, client *Client, zone string) ([]dynect.Record, serviceNodes, error) {
+	var nodes serviceNodes
+	records, err := client.GetZoneRecords(ctx, zone)
+	if err != nil {
+		return nil, nodes, fmt.Errorf("Failed to list the records of Dyn zone %s: %s", zone, err)
+	}
+	nodes, err = client.GetServiceNodes(ctx, zone)
+	if err != nil {
+		return nil, nodes, err
+	}
+	return withoutServiceRecords(zoneFileRecords(records), nodes), nodes, nil
+}
+
+// resourceDynZoneFileUpdate makes the live records of the zone match the zone
+// file, in a single publish, without recreating the zone.
+func resourceDynZoneFileUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	zone := d.Id()
+	desired, err := parseZoneFile(d.Get("zone_file").(string), zone)
+	if err != nil {
+		return fmt.Errorf("invalid zone file for %s: %s", zone, err)
+	}
+	live, nodes, err := resourceDynZoneFileLive(ctx, client, zone)
+	if err != nil {
+		return err
+	}
+
+	changes := diffRecordSets(live, withoutServiceRecords(zoneFileRecords(desired), nodes))
+	if len(changes) == 0 {
+		log.Printf("[INFO] Dyn zone %s already matches its zone file", zone)
+		return resourceDynZoneFileReadLocked(ctx, d, client)
+	}
+
+	notes, err := renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_zone_file "+zone, zone)
+	if err != nil {
+		return err
+	}
+	err = publishZoneChanges(ctx, client, zone, notes, func() error {
+		return stageRecordSetChanges(ctx, client, zone, changes)
+	})
+	if err != nil {
+		if jobID := pendingJobID(err); jobID != 0 {
+			log.Printf("[WARN] Dyn publish job %d for %s is still running", jobID, zone)
+			d.Set("pending_job_id", jobID)
+		}
+		return err
+	}
+
+	if !client.autoPublishes(zone) {
+		resourceDynZoneFileStaged(d)
+		return nil
+	}
+	return resourceDynZoneFileReadLocked(ctx, d, client)
+}
+
+// resourceDynZoneFileStaged records the state of changes staged on a zone
+// published manually, which follows the zone file until they are published.
+func resourceDynZoneFileStaged(d *schema.ResourceData) {
+	if records, err := parseZoneFile(d.Get("zone_file").(string), d.Id()); err == nil {
+		d.Set("records_sha256", zoneRecordsSHA256(records))
+	}
+	d.Set("pending_publish", true)
+}
+
+func resourceDynZoneFileDelete(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("delete_zone").(bool) {
+		// the zone is left in place unless asked for, as deleting it takes
+		// down every name in it
+		log.Printf("[INFO] Removing Dyn zone %s from state, it is left in place", d.Id())
+		return nil
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	log.Printf("[INFO] Deleting Dyn zone %s", d.Id())
+	if err := client.DeleteZone(ctx, d.Id()); err != nil {
+		return zoneFrozenError(d.Id(), fmt.Errorf("Failed to delete Dyn zone %s: %s", d.Id(), err))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDynZoneFile_basic(t *testing.T) {
+	// the zone is created and deleted by the test
+	zone := os.Getenv("DYN_NEW_ZONE")
+	if zone == "" {
+		t.Skip("DYN_NEW_ZONE must be set to a zone that does not exist yet for this acceptance test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynZoneFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneFileConfig, zone, zone, "192.168.0.10"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_zone_file.foobar", "zone", zone),
+					resource.TestCheckResourceAttrSet("dyn_zone_file.foobar", "records_sha256"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneFileConfig, zone, zone, "192.168.0.11"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_zone_file.foobar", "zone", zone),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDynZoneFileDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dyn_zone_file" {
+			continue
+		}
+
+		if _, err := client.GetZone(context.Background(), rs.Primary.ID); err == nil {
+			return fmt.Errorf("Zone still exists")
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDynZoneFileConfig = `
+resource "dyn_zone_file" "foobar" {
+	zone = "%s"
+	delete_zone = true
+	zone_file = <<ZONE
+$ORIGIN %s.
+$TTL 3600
+@	IN	SOA	ns1.p01.dynect.net. hostmaster.example.com. 1 3600 600 604800 1800
+www	300	IN	A	%s
+mail	IN	CNAME	www
+ZONE
+}`
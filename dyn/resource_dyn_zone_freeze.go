@@ -0,0 +1,87 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynZoneFreeze() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynZoneFreezeCreate,
+		Read:   resourceDynZoneFreezeRead,
+		Delete: resourceDynZoneFreezeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceDynZoneFreezeCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	log.Printf("[INFO] Freezing Dyn zone %s", zone)
+	if err := client.FreezeZone(ctx, zone); err != nil {
+		return fmt.Errorf("Failed to freeze Dyn zone %s: %s", zone, err)
+	}
+
+	d.SetId(zone)
+	return nil
+}
+
+func resourceDynZoneFreezeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	zone, err := client.GetZone(ctx, d.Id())
+	if err != nil {
+		return fmt.Errorf("Couldn't find Dyn zone %s: %s", d.Id(), err)
+	}
+	if zone.Frozen != nil && !*zone.Frozen {
+		log.Printf("[WARN] Dyn zone %s was thawed outside of Terraform", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("zone", d.Id())
+	return nil
+}
+
+func resourceDynZoneFreezeDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	log.Printf("[INFO] Thawing Dyn zone %s", d.Id())
+	if err := client.ThawZone(ctx, d.Id()); err != nil {
+		return fmt.Errorf("Failed to thaw Dyn zone %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
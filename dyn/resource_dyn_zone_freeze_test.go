@@ -0,0 +1,51 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDynZoneFreeze_basic(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneFreezeConfig_basic, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_zone_freeze.foobar", "zone", zone),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testAccCheckDynZoneFreezeConfig_record, zone, zone),
+				ExpectError: regexp.MustCompile("is frozen"),
+			},
+		},
+	})
+}
+
+const testAccCheckDynZoneFreezeConfig_basic = `
+resource "dyn_zone_freeze" "foobar" {
+	zone = "%s"
+}`
+
+const testAccCheckDynZoneFreezeConfig_record = `
+resource "dyn_zone_freeze" "foobar" {
+	zone = "%s"
+}
+
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform-frozen"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+	depends_on = ["dyn_zone_freeze.foobar"]
+}`
@@ -0,0 +1,132 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceDynZonePublish() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynZonePublishCreate,
+		Read:   resourceDynZonePublishRead,
+		Update: resourceDynZonePublishUpdate,
+		Delete: resourceDynZonePublishDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+
+			"changes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"pending_job_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDynZonePublishCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+
+	notes, err := renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_zone_publish "+zone, zone)
+	if err != nil {
+		return err
+	}
+
+	// every pending change is published, whoever made it
+	changes, err := client.GetZoneChanges(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("Failed to list pending changes of Dyn zone %s: %s", zone, err)
+	}
+	descriptions := make([]string, len(changes))
+	for i, c := range changes {
+		descriptions[i] = describeZoneChange(c)
+	}
+	log.Printf("[INFO] Publishing Dyn zone %s with %d pending changes", zone, len(changes))
+
+	d.SetId(zone)
+	d.Set("changes", descriptions)
+
+	if err := client.PublishZone(ctx, zone, notes); err != nil {
+		if jobID := pendingJobID(err); jobID != 0 {
+			log.Printf("[WARN] Dyn publish job %d for %s is still running", jobID, zone)
+			d.Set("pending_job_id", jobID)
+			// the next refresh waits for the publish, an error would taint it
+			return nil
+		}
+		d.SetId("")
+		return zoneFrozenError(zone, fmt.Errorf("Failed to publish Dyn zone: %s", err))
+	}
+
+	return nil
+}
+
+func resourceDynZonePublishRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	// resume waiting on a publish that was still running at the end of the
+	// last run
+	if jobID := d.Get("pending_job_id").(int); jobID != 0 {
+		log.Printf("[INFO] Waiting for pending Dyn job %d", jobID)
+		err := client.WaitJob(ctx, jobID, nil)
+		if pendingJobID(err) != 0 {
+			return fmt.Errorf("Dyn zone is waiting on a publish: %s", err)
+		}
+		if err != nil {
+			log.Printf("[WARN] Pending Dyn job %d did not succeed, the zone will be published again: %s", jobID, err)
+			d.SetId("")
+			return nil
+		}
+		d.Set("pending_job_id", 0)
+	}
+
+	return nil
+}
+
+func resourceDynZonePublishUpdate(d *schema.ResourceData, meta interface{}) error {
+	// publish_notes only applies to later publishes
+	return resourceDynZonePublishRead(d, meta)
+}
+
+func resourceDynZonePublishDelete(d *schema.ResourceData, meta interface{}) error {
+	// a publish cannot be undone, forgetting it is all there is to do
+	log.Printf("[INFO] Removing the publish of Dyn zone %s from state", d.Id())
+	return nil
+}
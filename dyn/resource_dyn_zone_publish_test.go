@@ -0,0 +1,83 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func TestAccDynZonePublish_manual(t *testing.T) {
+	var record dynect.Record
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDynRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynZonePublishConfig_staged, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_record.foobar", "pending_publish", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynZonePublishConfig_published, zone, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_zone_publish.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_zone_publish.foobar", "changes.#", "1"),
+					resource.TestCheckResourceAttr("dyn_zone_publish.foobar", "pending_job_id", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynZonePublishConfig_published, zone, zone, zone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynRecordExists("dyn_record.foobar", &record),
+					testAccCheckDynRecordAttributes(&record),
+					resource.TestCheckResourceAttr("dyn_record.foobar", "pending_publish", "false"),
+				),
+			},
+			{
+				// publish the destruction of the record too
+				Config: fmt.Sprintf(testAccCheckDynRecordConfig_basic, zone),
+			},
+		},
+	})
+}
+
+const testAccCheckDynZonePublishConfig_staged = `
+provider "dyn" {
+	manual_publish_zones = ["%s"]
+}
+
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+}`
+
+const testAccCheckDynZonePublishConfig_published = `
+provider "dyn" {
+	manual_publish_zones = ["%s"]
+}
+
+resource "dyn_record" "foobar" {
+	zone = "%s"
+	name = "terraform"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+}
+
+resource "dyn_zone_publish" "foobar" {
+	zone = "%s"
+	triggers = {
+		change = "terraform-acc-test"
+	}
+	depends_on = ["dyn_record.foobar"]
+}`
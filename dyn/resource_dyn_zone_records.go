@@ -0,0 +1,397 @@
+package dyn
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// zoneRecordTypes are the record types dyn_zone_records can manage. SOA
+// records are left to Dyn.
+var zoneRecordTypes = []string{"A", "AAAA", "ALIAS", "CNAME", "MX", "NS", "PTR", "SPF", "SRV", "TXT"}
+
+// isZoneRecordType reports whether dyn_zone_records can manage records of
+// recordType.
+func isZoneRecordType(recordType string) bool {
+	for _, t := range zoneRecordTypes {
+		if t == recordType {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceDynZoneRecords() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDynZoneRecordsCreate,
+		Read:   resourceDynZoneRecordsRead,
+		Update: resourceDynZoneRecordsUpdate,
+		Delete: resourceDynZoneRecordsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDynZoneRecordsImportState,
+		},
+
+		CustomizeDiff: resourceDynZoneRecordsCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"record": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      zoneRecordHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateRecordName,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringInSlice(zoneRecordTypes),
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ttl": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3600,
+							ValidateFunc: validateIntBetween(1, maxTTL),
+						},
+					},
+				},
+			},
+
+			"exclude_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"exclude_apex_ns": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"exclude_names": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"publish_notes": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePublishNotes,
+			},
+
+			"pending_job_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"pending_publish": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// zoneRecordHash identifies a record of dyn_zone_records by its canonical
+// form, so that equivalent ways of writing it do not cause a diff. The zone
+// is not known here, so a name equal to the zone is not folded into the
+// apex: Read keeps names as they are configured instead.
+func zoneRecordHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	recordType := m["type"].(string)
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s-", normalizeRecordName(m["name"].(string), "")))
+	buf.WriteString(fmt.Sprintf("%s-", recordType))
+	buf.WriteString(fmt.Sprintf("%s-", normalizeRecordValue(recordType, m["value"].(string))))
+	buf.WriteString(fmt.Sprintf("%d-", m["ttl"].(int)))
+	return hashcode.String(buf.String())
+}
+
+// resourceDynZoneRecordsFilter returns the records of the zone that the
+// resource leaves alone.
+func resourceDynZoneRecordsFilter(d resourceGetter) *recordFilter {
+	filter := &recordFilter{
+		ApexNS: d.Get("exclude_apex_ns").(bool),
+	}
+	for _, t := range d.Get("exclude_types").(*schema.Set).List() {
+		filter.Types = append(filter.Types, t.(string))
+	}
+	for _, name := range d.Get("exclude_names").([]interface{}) {
+		filter.Names = append(filter.Names, name.(string))
+	}
+	return filter
+}
+
+// resourceGetter is what ResourceData and ResourceDiff have in common.
+type resourceGetter interface {
+	Get(string) interface{}
+}
+
+// resourceDynZoneRecordsDesired returns the records declared by the
+// resource.
+func resourceDynZoneRecordsDesired(d resourceGetter) []dynect.Record {
+	zone := d.Get("zone").(string)
+
+	var records []dynect.Record
+	for _, v := range d.Get("record").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		name := normalizeRecordName(m["name"].(string), zone)
+		records = append(records, dynect.Record{
+			Zone:  zone,
+			Name:  name,
+			FQDN:  recordFQDN(name, zone),
+			Type:  m["type"].(string),
+			TTL:   recordTTL(m["ttl"].(int)),
+			Value: m["value"].(string),
+		})
+	}
+	return records
+}
+
+func resourceDynZoneRecordsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("zone") || !d.NewValueKnown("record") {
+		return nil
+	}
+
+	filter := resourceDynZoneRecordsFilter(d)
+	types := make(map[string]map[string]bool)
+	for _, r := range resourceDynZoneRecordsDesired(d) {
+		if err := checkRecordValue(r.Type, r.Value); err != nil {
+			return fmt.Errorf("invalid value for %s record %s: %s", r.Type, r.FQDN, err)
+		}
+		if r.Type == "CNAME" && r.Name == "" {
+			return fmt.Errorf("a CNAME record cannot be created at the apex of %s", r.Zone)
+		}
+		if filter.Excluded(r.Type, r.Name) {
+			return fmt.Errorf("%s record %s is excluded from the records managed by dyn_zone_records", r.Type, r.FQDN)
+		}
+
+		if types[r.Name] == nil {
+			types[r.Name] = make(map[string]bool)
+		}
+		types[r.Name][r.Type] = true
+		if types[r.Name]["CNAME"] && len(types[r.Name]) > 1 {
+			return fmt.Errorf("a CNAME record cannot share its name with other records, but %s has several types", r.FQDN)
+		}
+	}
+
+	// show unpublished changes that would be published along with this one
+	// at plan time already
+	zone := d.Get("zone").(string)
+	if client, ok := meta.(*Client); ok && client.autoPublishes(zone) && (d.Id() == "" || d.HasChange("record")) {
+		_, err := checkForeignZoneChanges(client.StopContext(), client, zone)
+		return err
+	}
+	return nil
+}
+
+func resourceDynZoneRecordsCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceDynZoneRecordsApply(d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+	return resourceDynZoneRecordsRead(d, meta)
+}
+
+func resourceDynZoneRecordsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("record") && !d.HasChange("exclude_types") && !d.HasChange("exclude_apex_ns") && !d.HasChange("exclude_names") {
+		// publish_notes only applies to later publishes
+		return resourceDynZoneRecordsRead(d, meta)
+	}
+	if err := resourceDynZoneRecordsApply(d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+	return resourceDynZoneRecordsRead(d, meta)
+}
+
+// resourceDynZoneRecordsApply makes the live records of the zone match the
+// configuration, in a single publish.
+func resourceDynZoneRecordsApply(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), timeout)
+	defer cancel()
+
+	zone := d.Get("zone").(string)
+	live, nodes, err := resourceDynZoneRecordsLive(ctx, d, client)
+	if err != nil {
+		return err
+	}
+	changes := diffRecordSets(live, withoutServiceRecords(resourceDynZoneRecordsDesired(d), nodes))
+
+	d.SetId(zone)
+	if len(changes) == 0 {
+		log.Printf("[INFO] Dyn zone %s already has the configured records", zone)
+		return nil
+	}
+
+	notes, err := renderPublishNotes(client, d.Get("publish_notes").(string), "dyn_zone_records "+zone, zone)
+	if err != nil {
+		return err
+	}
+
+	err = publishZoneChanges(ctx, client, zone, notes, func() error {
+		return stageRecordSetChanges(ctx, client, zone, changes)
+	})
+	if err != nil {
+		if jobID := pendingJobID(err); jobID != 0 {
+			log.Printf("[WARN] Dyn publish job %d for %s is still running", jobID, zone)
+			d.Set("pending_job_id", jobID)
+		}
+		return err
+	}
+
+	if !client.autoPublishes(zone) {
+		d.Set("pending_publish", true)
+	}
+	return nil
+}
+
+// resourceDynZoneRecordsLive lists the live records of the zone managed by
+// the resource, and returns the nodes of the services of the zone. Records
+// belonging to services such as HTTP redirects are left alone.
+func resourceDynZoneRecordsLive(ctx context.Context, d *schema.ResourceData, client *Client) ([]dynect.Record, serviceNodes, error) {
+	zone := d.Get("zone").(string)
+	records, err := client.GetZoneRecords(ctx, zone)
+	if err != nil {
+		return nil, serviceNodes{}, fmt.Errorf("Failed to list the records of Dyn zone %s: %s", zone, err)
+	}
+	nodes, err := client.GetServiceNodes(ctx, zone)
+	if err != nil {
+		return nil, nodes, err
+	}
+	records = withoutServiceRecords(records, nodes)
+
+	filter := resourceDynZoneRecordsFilter(d)
+	var managed []dynect.Record
+	for _, r := range records {
+		// records of types that cannot be declared are left alone
+		if !isZoneRecordType(r.Type) {
+			continue
+		}
+		if !filter.Excluded(r.Type, r.Name) {
+			managed = append(managed, r)
+		}
+	}
+	return managed, nodes, nil
+}
+
+func resourceDynZoneRecordsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(client.StopContext(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	zone := d.Id()
+	d.Set("zone", zone)
+
+	// resume waiting on a publish that was still running at the end of the
+	// last run
+	if jobID := d.Get("pending_job_id").(int); jobID != 0 {
+		log.Printf("[INFO] Waiting for pending Dyn job %d", jobID)
+		err := client.WaitJob(ctx, jobID, nil)
+		if pendingJobID(err) != 0 {
+			return fmt.Errorf("Dyn zone records are waiting on a publish: %s", err)
+		}
+		if err != nil {
+			log.Printf("[WARN] Pending Dyn job %d did not succeed, the changes will be planned again: %s", jobID, err)
+		}
+		d.Set("pending_job_id", 0)
+	}
+
+	// keep changes staged on a zone published manually in state until they
+	// are published or discarded
+	if d.Get("pending_publish").(bool) {
+		changes, err := client.GetZoneChanges(ctx, zone)
+		if err != nil {
+			return fmt.Errorf("Failed to list pending changes of Dyn zone %s: %s", zone, err)
+		}
+		if len(changes) > 0 {
+			log.Printf("[INFO] Dyn zone %s has %d changes waiting to be published", zone, len(changes))
+			return nil
+		}
+		d.Set("pending_publish", false)
+	}
+
+	live, nodes, err := resourceDynZoneRecordsLive(ctx, d, client)
+	if err != nil {
+		return err
+	}
+
+	// live records are named as they are configured, such as the apex by
+	// the name of the zone, so that they hash the same
+	names := make(map[string]string)
+	for _, v := range d.Get("record").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		recordType := m["type"].(string)
+		key := strings.Join([]string{normalizeRecordName(m["name"].(string), zone), recordType, normalizeRecordValue(recordType, m["value"].(string))}, "/")
+		names[key] = m["name"].(string)
+	}
+
+	records := make([]map[string]interface{}, 0, len(live))
+	for _, r := range live {
+		name := r.Name
+		if configured, ok := names[strings.Join([]string{r.Name, r.Type, normalizeRecordValue(r.Type, r.Value)}, "/")]; ok {
+			name = configured
+		}
+		records = append(records, map[string]interface{}{
+			"name":  name,
+			"type":  r.Type,
+			"value": normalizeRecordValue(r.Type, r.Value),
+			"ttl":   stateTTL(r.TTL),
+		})
+	}
+	// declared records that belong to a service are never applied, and are
+	// kept as declared so that they do not show up as a diff
+	for _, v := range d.Get("record").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		r := dynect.Record{Name: normalizeRecordName(m["name"].(string), zone), Type: m["type"].(string)}
+		if nodes.owns(r) {
+			log.Printf("[WARN] Dyn %s record %s belongs to a service and is left alone", r.Type, recordFQDN(r.Name, zone))
+			records = append(records, m)
+		}
+	}
+	return d.Set("record", records)
+}
+
+func resourceDynZoneRecordsDelete(d *schema.ResourceData, meta interface{}) error {
+	// the records are left in place, as deleting every record of the zone is
+	// never what destroying the resource is meant to do
+	log.Printf("[INFO] Removing the records of Dyn zone %s from state, they are left in place", d.Id())
+	return nil
+}
+
+func resourceDynZoneRecordsImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("zone", d.Id())
+	d.Set("exclude_apex_ns", true)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,107 @@
+package dyn
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDynZoneRecords_basic(t *testing.T) {
+	// every other record of the zone is deleted, so the test needs a zone of
+	// its own
+	zone := os.Getenv("DYN_AUTHORITATIVE_ZONE")
+	if zone == "" {
+		t.Skip("DYN_AUTHORITATIVE_ZONE must be set to a disposable zone for this acceptance test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneRecordsConfig_basic, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_zone_records.foobar", "zone", zone),
+					resource.TestCheckResourceAttr("dyn_zone_records.foobar", "record.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckDynZoneRecordsConfig_updated, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dyn_zone_records.foobar", "record.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "dyn_zone_records.foobar",
+				ImportState:       true,
+				ImportStateId:     zone,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDynZoneRecords_excluded(t *testing.T) {
+	zone := os.Getenv("DYN_ZONE")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccCheckDynZoneRecordsConfig_excluded, zone),
+				ExpectError: regexp.MustCompile("is excluded from the records managed by dyn_zone_records"),
+			},
+		},
+	})
+}
+
+const testAccCheckDynZoneRecordsConfig_basic = `
+resource "dyn_zone_records" "foobar" {
+	zone = "%s"
+
+	record {
+		name = "terraform"
+		type = "A"
+		value = "192.168.0.10"
+	}
+
+	record {
+		name = "terraform"
+		type = "A"
+		value = "192.168.0.11"
+	}
+}`
+
+const testAccCheckDynZoneRecordsConfig_updated = `
+resource "dyn_zone_records" "foobar" {
+	zone = "%s"
+
+	record {
+		name = "terraform"
+		type = "A"
+		value = "192.168.0.10"
+		ttl = 300
+	}
+
+	record {
+		name = "terraform"
+		type = "TXT"
+		value = "managed by terraform"
+	}
+}`
+
+const testAccCheckDynZoneRecordsConfig_excluded = `
+resource "dyn_zone_records" "foobar" {
+	zone = "%s"
+	exclude_names = ["legacy*"]
+
+	record {
+		name = "legacy"
+		type = "A"
+		value = "192.168.0.10"
+	}
+}`
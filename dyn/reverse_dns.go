@@ -0,0 +1,77 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// ReverseDNS is a Reverse DNS service attached to a node of a reverse zone,
+// which generates PTR records below it for the forward records of its hosts
+// whose addresses are in its network.
+type ReverseDNS struct {
+	Zone        string      `json:"zone,omitempty"`
+	FQDN        string      `json:"fqdn,omitempty"`
+	ServiceID   looseString `json:"iptrack_id,omitempty"`
+	Hosts       []string    `json:"hosts"`
+	RecordTypes []string    `json:"record_types"`
+	Netmask     string      `json:"netmask"`
+	TTL         looseString `json:"ttl"`
+	Active      string      `json:"active,omitempty"`
+	Publish     string      `json:"publish,omitempty"`
+}
+
+type reverseDNSResponse struct {
+	dynect.ResponseBlock
+	Data ReverseDNS `json:"data"`
+}
+
+// reverseDNSURL is the endpoint of the Reverse DNS service id of fqdn.
+func reverseDNSURL(zone, fqdn, id string) string {
+	return "IPTrack/" + zone + "/" + fqdn + "/" + id + "/"
+}
+
+// GetReverseDNSID returns the id of the Reverse DNS service of fqdn, which
+// must be the only one attached to it.
+func (c *Client) GetReverseDNSID(ctx context.Context, zone, fqdn string) (string, error) {
+	var resp serviceListResponse
+	if err := c.DoContext(ctx, "GET", "IPTrack/"+zone+"/"+fqdn+"/", nil, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Data) != 1 {
+		return "", fmt.Errorf("%s has %d Reverse DNS services attached, expected 1", fqdn, len(resp.Data))
+	}
+	parts := strings.Split(strings.Trim(resp.Data[0], "/"), "/")
+	return parts[len(parts)-1], nil
+}
+
+// GetReverseDNS reads the Reverse DNS service id of fqdn.
+func (c *Client) GetReverseDNS(ctx context.Context, zone, fqdn, id string) (*ReverseDNS, error) {
+	var resp reverseDNSResponse
+	if err := c.DoContext(ctx, "GET", reverseDNSURL(zone, fqdn, id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateReverseDNS stages a Reverse DNS service at fqdn, and returns its id.
+func (c *Client) CreateReverseDNS(ctx context.Context, zone, fqdn string, reverse *ReverseDNS) (string, error) {
+	var resp reverseDNSResponse
+	if err := c.DoContext(ctx, "POST", "IPTrack/"+zone+"/"+fqdn+"/", reverse, &resp); err != nil {
+		return "", err
+	}
+	return string(resp.Data.ServiceID), nil
+}
+
+// UpdateReverseDNS stages changes to the Reverse DNS service id of fqdn.
+func (c *Client) UpdateReverseDNS(ctx context.Context, zone, fqdn, id string, reverse *ReverseDNS) error {
+	return c.DoContext(ctx, "PUT", reverseDNSURL(zone, fqdn, id), reverse, nil)
+}
+
+// DeleteReverseDNS stages the deletion of the Reverse DNS service id of fqdn,
+// along with the PTR records it generated.
+func (c *Client) DeleteReverseDNS(ctx context.Context, zone, fqdn, id string) error {
+	return c.DoContext(ctx, "DELETE", reverseDNSURL(zone, fqdn, id), nil, nil)
+}
@@ -0,0 +1,70 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestReverseDNS(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/REST/IPTrack/8.b.d.0.1.0.0.2.ip6.arpa/8.b.d.0.1.0.0.2.ip6.arpa/":
+			if r.Method == "POST" {
+				b, _ := ioutil.ReadAll(r.Body)
+				body = string(b)
+				fmt.Fprint(w, `{"status": "success", "data": {"iptrack_id": 7}}`)
+				return
+			}
+			fmt.Fprint(w, `{"status": "success", "data": [
+				"/REST/IPTrack/8.b.d.0.1.0.0.2.ip6.arpa/8.b.d.0.1.0.0.2.ip6.arpa/7/"
+			]}`)
+		case "/REST/IPTrack/8.b.d.0.1.0.0.2.ip6.arpa/8.b.d.0.1.0.0.2.ip6.arpa/7/":
+			fmt.Fprint(w, `{"status": "success", "data": {"zone": "8.b.d.0.1.0.0.2.ip6.arpa",
+				"fqdn": "8.b.d.0.1.0.0.2.ip6.arpa", "iptrack_id": "7", "hosts": ["www.example.com."],
+				"record_types": ["AAAA"], "netmask": "2001:db8::/32", "ttl": 3600, "active": "Y"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	zone := "8.b.d.0.1.0.0.2.ip6.arpa"
+	id, err := client.CreateReverseDNS(context.Background(), zone, zone, &ReverseDNS{
+		Hosts: []string{"www.example.com"}, RecordTypes: []string{"AAAA"}, Netmask: "2001:db8::/32", TTL: "0", Publish: "N",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := `{"hosts":["www.example.com"],"record_types":["AAAA"],"netmask":"2001:db8::/32","ttl":"0","publish":"N"}`
+	if id != "7" || body != expected {
+		t.Fatalf("expected id 7 and %s, got %q and %s", expected, id, body)
+	}
+
+	id, err = client.GetReverseDNSID(context.Background(), zone, zone)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id != "7" {
+		t.Fatalf("expected id 7, got %q", id)
+	}
+
+	reverse, err := client.GetReverseDNS(context.Background(), zone, zone, id)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(reverse.RecordTypes, []string{"AAAA"}) || reverse.Netmask != "2001:db8::/32" || looseInt(reverse.TTL) != 3600 {
+		t.Fatalf("unexpected service: %#v", reverse)
+	}
+
+	_, err = client.GetReverseDNSID(context.Background(), zone, "1."+zone)
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
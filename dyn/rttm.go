@@ -0,0 +1,72 @@
+package dyn
+
+import (
+	"context"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// RTTM is a Real Time Traffic Manager service attached to a node, which
+// serves each region the addresses of its pool that respond the fastest.
+// Fields that can be cleared are sent even when empty, as Dyn keeps the
+// current value of a field left out of an update.
+type RTTM struct {
+	Zone               string         `json:"zone,omitempty"`
+	FQDN               string         `json:"fqdn,omitempty"`
+	Status             string         `json:"status,omitempty"`
+	ContactNickname    string         `json:"contact_nickname"`
+	TTL                looseString    `json:"ttl"`
+	AutoRecover        string         `json:"auto_recover"`
+	NotifyEvents       string         `json:"notify_events"`
+	SyslogServer       string         `json:"syslog_server"`
+	SyslogPort         looseString    `json:"syslog_port,omitempty"`
+	SyslogIdent        string         `json:"syslog_ident,omitempty"`
+	SyslogFacility     string         `json:"syslog_facility,omitempty"`
+	SyslogDelivery     string         `json:"syslog_delivery,omitempty"`
+	Monitor            ServiceMonitor `json:"monitor"`
+	PerformanceMonitor ServiceMonitor `json:"performance_monitor"`
+	Region             []RTTMRegion   `json:"region"`
+	Publish            string         `json:"publish,omitempty"`
+}
+
+// RTTMRegion is the pool of addresses an RTTM service chooses from for a
+// region. Its addresses are described like those of a GSLB pool.
+type RTTMRegion struct {
+	RegionCode   string        `json:"region_code"`
+	Autopopulate string        `json:"autopopulate"`
+	ServeCount   looseString   `json:"serve_count"`
+	FailoverMode string        `json:"failover_mode"`
+	FailoverData string        `json:"failover_data"`
+	Pool         []GSLBAddress `json:"pool"`
+}
+
+type rttmResponse struct {
+	dynect.ResponseBlock
+	Data RTTM `json:"data"`
+}
+
+// GetRTTM reads the RTTM service of fqdn.
+func (c *Client) GetRTTM(ctx context.Context, zone, fqdn string) (*RTTM, error) {
+	var resp rttmResponse
+	if err := c.DoContext(ctx, "GET", "RTTM/"+zone+"/"+fqdn+"/", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// CreateRTTM stages an RTTM service at fqdn.
+func (c *Client) CreateRTTM(ctx context.Context, zone, fqdn string, rttm *RTTM) error {
+	return c.DoContext(ctx, "POST", "RTTM/"+zone+"/"+fqdn+"/", rttm, nil)
+}
+
+// UpdateRTTM stages changes to the RTTM service of fqdn, replacing its
+// regions and their pools.
+func (c *Client) UpdateRTTM(ctx context.Context, zone, fqdn string, rttm *RTTM) error {
+	return c.DoContext(ctx, "PUT", "RTTM/"+zone+"/"+fqdn+"/", rttm, nil)
+}
+
+// DeleteRTTM stages the deletion of the RTTM service of fqdn, along with its
+// records.
+func (c *Client) DeleteRTTM(ctx context.Context, zone, fqdn string) error {
+	return c.DoContext(ctx, "DELETE", "RTTM/"+zone+"/"+fqdn+"/", nil, nil)
+}
@@ -0,0 +1,81 @@
+package dyn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetRTTM(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/RTTM/example.com/api.example.com/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "fqdn": "api.example.com", "status": "ok",
+			"contact_nickname": "owner", "ttl": 30, "auto_recover": "Y",
+			"syslog_server": "syslog.example.com", "syslog_port": "514", "syslog_ident": "dynect",
+			"syslog_facility": "local0", "syslog_delivery": "all",
+			"monitor": {"protocol": "HTTPS", "interval": 1, "path": "/health"},
+			"performance_monitor": {"protocol": "HTTPS", "interval": "20", "path": "/ping"},
+			"region": [
+				{"region_code": "EU West", "autopopulate": "N", "serve_count": 1, "failover_mode": "global", "pool": [
+					{"address": "192.168.1.10", "weight": 1, "serve_mode": "obey", "status": "up"}
+				]},
+				{"region_code": "global", "autopopulate": "Y", "serve_count": "2", "failover_mode": "global", "pool": [
+					{"address": "192.168.0.10", "label": "primary", "weight": "10", "serve_mode": "obey", "status": "up"}
+				]}
+			]}}`)
+	}))
+	defer server.Close()
+
+	rttm, err := testClient(server).GetRTTM(context.Background(), "example.com", "api.example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if rttm.Status != "ok" || rttm.SyslogDelivery != "all" || looseInt(rttm.SyslogPort) != 514 {
+		t.Fatalf("unexpected service: %#v", rttm)
+	}
+
+	performance := flattenPerformanceMonitor(rttm.PerformanceMonitor)
+	if performance[0]["interval"] != 20 || performance[0]["path"] != "/ping" {
+		t.Fatalf("unexpected performance monitor: %#v", performance)
+	}
+
+	configured := []interface{}{
+		map[string]interface{}{"region_code": "global"},
+		map[string]interface{}{"region_code": "EU West"},
+	}
+	regions := flattenRTTMRegions(rttm.Region, configured)
+	if regions[0]["region_code"] != "global" || regions[1]["region_code"] != "EU West" {
+		t.Fatalf("regions are not in the configured order: %#v", regions)
+	}
+	if regions[0]["autopopulate"] != true || regions[1]["autopopulate"] != false {
+		t.Fatalf("unexpected autopopulate: %#v", regions)
+	}
+	pool := regions[0]["pool"].([]map[string]interface{})
+	expected := map[string]interface{}{
+		"address": "192.168.0.10", "label": "primary", "weight": 10, "serve_mode": "obey", "status": "up",
+	}
+	if !reflect.DeepEqual(pool[0], expected) {
+		t.Fatalf("expected %#v, got %#v", expected, pool[0])
+	}
+}
+
+func TestRTTM_clearedFields(t *testing.T) {
+	// fields left out of an update keep their value on Dyn's side
+	body, err := json.Marshal(RTTM{Region: []RTTMRegion{{RegionCode: "global"}}})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, field := range []string{`"notify_events":""`, `"syslog_server":""`, `"failover_data":""`} {
+		if !strings.Contains(string(body), field) {
+			t.Errorf("expected %s in %s", field, body)
+		}
+	}
+}
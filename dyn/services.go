@@ -0,0 +1,248 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// serviceAddressTypes are the types of the records a traffic management or
+// redirect service answers with at its node.
+var serviceAddressTypes = []string{"A", "AAAA", "CNAME"}
+
+// zoneServices are the Dyn services that create or update records at the
+// nodes they are attached to, with the types of the records they own there.
+// Those records belong to the service, and are left out of the comparisons
+// of live records with the configuration. Other records at the node, such as
+// MX or TXT records, do not.
+var zoneServices = []struct {
+	Name  string
+	Types []string
+}{
+	{"AdvRedirect", serviceAddressTypes},
+	{"DDNS", []string{"A", "AAAA"}},
+	{"Failover", serviceAddressTypes},
+	{"GSLB", serviceAddressTypes},
+	{"HTTPRedirect", serviceAddressTypes},
+	{"LoadBalance", serviceAddressTypes},
+	{"RTTM", serviceAddressTypes},
+}
+
+// serviceListResponse holds the URIs of the services of a zone, as in
+// "/REST/HTTPRedirect/example.com/www.example.com/".
+type serviceListResponse struct {
+	dynect.ResponseBlock
+	Data []string `json:"data"`
+}
+
+// serviceNodes are the nodes of a zone that have a service attached, by name
+// relative to the zone.
+type serviceNodes struct {
+	// Types are the types of the records owned by the services of each
+	// node.
+	Types map[string]map[string]bool
+
+	// PTRNodes are those with a Reverse DNS service attached, which owns
+	// the PTR records it generates at and below them.
+	PTRNodes map[string]bool
+}
+
+// GetServiceNodes returns the nodes of zone that have a service attached.
+func (c *Client) GetServiceNodes(ctx context.Context, zone string) (serviceNodes, error) {
+	nodes := serviceNodes{Types: make(map[string]map[string]bool), PTRNodes: make(map[string]bool)}
+	for _, service := range zoneServices {
+		uris, err := c.listServices(ctx, service.Name, zone)
+		if err != nil {
+			return nodes, err
+		}
+		for _, uri := range uris {
+			fqdn, recordType := serviceURINode(uri)
+			if fqdn == "" {
+				continue
+			}
+			name := relativeRecordName(fqdn, zone)
+			if nodes.Types[name] == nil {
+				nodes.Types[name] = make(map[string]bool)
+			}
+			types := service.Types
+			if recordType != "" {
+				// a DDNS service only owns the records of its type
+				types = []string{recordType}
+			}
+			for _, t := range types {
+				nodes.Types[name][t] = true
+			}
+		}
+	}
+
+	uris, err := c.listServices(ctx, "IPTrack", zone)
+	if err != nil {
+		return nodes, err
+	}
+	for _, uri := range uris {
+		if fqdn, _ := serviceURINode(uri); fqdn != "" {
+			nodes.PTRNodes[relativeRecordName(fqdn, zone)] = true
+		}
+	}
+	return nodes, nil
+}
+
+// listServices lists the URIs of the services of the given type in zone.
+func (c *Client) listServices(ctx context.Context, service, zone string) ([]string, error) {
+	var resp serviceListResponse
+	err := c.DoContext(ctx, "GET", service+"/"+zone+"/", nil, &resp)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list the %s services of Dyn zone %s: %s", service, zone, err)
+	}
+	return resp.Data, nil
+}
+
+// serviceURINode returns the node of a service URI, and the record type it
+// names for services such as DDNS that are attached to a record type.
+func serviceURINode(uri string) (string, string) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(uri, "/REST/"), "/"), "/")
+	if len(parts) < 3 {
+		return "", ""
+	}
+	if len(parts) > 3 && (parts[3] == "A" || parts[3] == "AAAA") {
+		return parts[2], parts[3]
+	}
+	return parts[2], ""
+}
+
+// withoutServiceRecords drops the records that belong to a service, as
+// listed by GetServiceNodes.
+func withoutServiceRecords(records []dynect.Record, nodes serviceNodes) []dynect.Record {
+	var kept []dynect.Record
+	for _, r := range records {
+		if nodes.owns(r) {
+			log.Printf("[DEBUG] Leaving out Dyn %s record %s, which belongs to a service", r.Type, r.FQDN)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// owns tells whether a record belongs to a service.
+func (nodes serviceNodes) owns(r dynect.Record) bool {
+	name := strings.ToLower(r.Name)
+	return nodes.Types[name][r.Type] || (r.Type == "PTR" && nodes.ownsPTR(name))
+}
+
+// ownsPTR tells whether a PTR record at name was generated by a Reverse DNS
+// service attached to it or to one of its parents.
+func (nodes serviceNodes) ownsPTR(name string) bool {
+	for {
+		if nodes.PTRNodes[name] {
+			return true
+		}
+		if name == "" {
+			return false
+		}
+		if i := strings.Index(name, "."); i >= 0 {
+			name = name[i+1:]
+		} else {
+			name = ""
+		}
+	}
+}
+
+// stageServiceChanges stages the changes made to a service by stage, and
+// publishes the zone unless publish is false, in which case the changes are
+// left pending like on a zone published manually.
+func stageServiceChanges(ctx context.Context, client *Client, zone, notes string, publish bool, stage func() error) error {
+	if !publish {
+		if err := zoneFrozenError(zone, stage()); err != nil {
+			return err
+		}
+		log.Printf("[INFO] Leaving the changes of Dyn zone %s pending, as publish is false", zone)
+		return nil
+	}
+	return publishZoneChanges(ctx, client, zone, notes, stage)
+}
+
+// yesNo renders a bool as the Dyn API takes it.
+func yesNo(b bool) string {
+	if b {
+		return "Y"
+	}
+	return "N"
+}
+
+// joinStringSet renders a set of strings as the comma separated list the
+// Dyn API takes, in a stable order.
+func joinStringSet(set *schema.Set) string {
+	return strings.Join(sortedStringSet(set), ",")
+}
+
+// sortedStringSet lists a set of strings in a stable order.
+func sortedStringSet(set *schema.Set) []string {
+	var values []string
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// splitStringSet parses a comma separated list returned by the Dyn API.
+func splitStringSet(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// blockOrder maps the keys of configured blocks to their position.
+type blockOrder map[string]int
+
+// configuredOrder records the position of each configured block by the value
+// of key, so that blocks read back from Dyn can be listed in the same order.
+func configuredOrder(configured []interface{}, key string) blockOrder {
+	order := make(blockOrder)
+	for i, v := range configured {
+		if m, ok := v.(map[string]interface{}); ok {
+			order[m[key].(string)] = i
+		}
+	}
+	return order
+}
+
+// less orders configured keys as they are configured, followed by the keys
+// of blocks added outside of Terraform.
+func (order blockOrder) less(a, b string) bool {
+	oa, aok := order[a]
+	ob, bok := order[b]
+	if aok != bok {
+		return aok
+	}
+	return oa < ob
+}
+
+// resourceDynServiceImportState imports a service attached to a node by
+// "zone/fqdn".
+func resourceDynServiceImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	values := strings.Split(d.Id(), "/")
+	if len(values) != 2 {
+		return nil, fmt.Errorf("invalid id provided, expected format: {zone}/{fqdn}")
+	}
+
+	zone, fqdn := values[0], strings.TrimSuffix(values[1], ".")
+	d.SetId(fqdn)
+	d.Set("zone", zone)
+	d.Set("name", relativeRecordName(fqdn, zone))
+	d.Set("fqdn", fqdn)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,164 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func TestGetServiceNodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/REST/HTTPRedirect/example.com/":
+			fmt.Fprint(w, `{"status": "success", "data": [
+				"/REST/HTTPRedirect/example.com/www.example.com/",
+				"/REST/HTTPRedirect/example.com/example.com/"
+			]}`)
+		case "/REST/DDNS/example.com/":
+			fmt.Fprint(w, `{"status": "success", "data": [
+				"/REST/DDNS/example.com/home.example.com/AAAA/"
+			]}`)
+		case "/REST/IPTrack/example.com/":
+			fmt.Fprint(w, `{"status": "success", "data": [
+				"/REST/IPTrack/example.com/rev.example.com/3/"
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	nodes, err := testClient(server).GetServiceNodes(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(nodes.Types) != 3 || !nodes.Types["www"]["CNAME"] || !nodes.Types[""]["A"] || nodes.Types[""]["MX"] {
+		t.Fatalf("unexpected nodes: %#v", nodes.Types)
+	}
+	if !nodes.Types["home"]["AAAA"] || nodes.Types["home"]["A"] {
+		t.Fatalf("a DDNS node should only own the records of its type: %#v", nodes.Types["home"])
+	}
+	if len(nodes.PTRNodes) != 1 || !nodes.PTRNodes["rev"] {
+		t.Fatalf("unexpected PTR nodes: %#v", nodes.PTRNodes)
+	}
+
+	records := []dynect.Record{
+		{Name: "www", Type: "A", Value: "192.168.0.10"},
+		{Name: "", Type: "A", Value: "192.168.0.10"},
+		{Name: "", Type: "MX", Value: "10 mail.example.com."},
+		{Name: "", Type: "TXT", Value: "v=spf1 -all"},
+		{Name: "home", Type: "AAAA", Value: "2001:db8::1"},
+		{Name: "home", Type: "A", Value: "192.168.0.12"},
+		{Name: "mail", Type: "A", Value: "192.168.0.11"},
+		{Name: "rev", Type: "PTR", Value: "host.example.com."},
+		{Name: "1.0.rev", Type: "PTR", Value: "host.example.com."},
+		{Name: "1.0.rev", Type: "TXT", Value: "kept"},
+		{Name: "1.0.other", Type: "PTR", Value: "host.example.com."},
+	}
+	kept := withoutServiceRecords(records, nodes)
+	var names []string
+	for _, r := range kept {
+		names = append(names, r.Type+" "+r.Name)
+	}
+	expected := []string{"MX ", "TXT ", "A home", "A mail", "TXT 1.0.rev", "PTR 1.0.other"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestHTTPRedirect(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/HTTPRedirect/example.com/www.example.com/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == "PUT" {
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "fqdn": "www.example.com", "code": 302, "keep_uri": "Y", "url": "https://example.org/"}}`)
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	redirect, err := client.GetHTTPRedirect(context.Background(), "example.com", "www.example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if redirect.Code != "302" || redirect.KeepURI != "Y" || redirect.URL != "https://example.org/" {
+		t.Fatalf("unexpected redirect: %#v", redirect)
+	}
+
+	err = client.UpdateHTTPRedirect(context.Background(), "example.com", "www.example.com", &httpRedirectRequest{
+		Code: "301", KeepURI: "N", URL: "https://example.org/", Publish: "N",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := `{"code":"301","keep_uri":"N","url":"https://example.org/","publish":"N"}`
+	if body != expected {
+		t.Fatalf("expected %s, got %s", expected, body)
+	}
+
+	_, err = client.GetHTTPRedirect(context.Background(), "example.com", "old.example.com")
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestAdvancedRedirect(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/AdvRedirect/example.com/www.example.com/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == "PUT" {
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "fqdn": "www.example.com", "active": "Y", "rules": [
+			{"public_id": "b", "host_prefix": "", "path": "/old/*", "url_pattern": "https://example.org/new/\\1", "code": 301, "active": "Y"},
+			{"public_id": "a", "host_prefix": "shop", "path": "", "url_pattern": "https://shop.example.org/", "code": "302", "active": "N"}
+		]}}`)
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	redirect, err := client.GetAdvancedRedirect(context.Background(), "example.com", "www.example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	rules, err := flattenAdvancedRedirectRules(redirect.Rules)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(rules) != 2 || rules[0]["public_id"] != "b" || rules[0]["code"] != 301 || rules[1]["active"] != false {
+		t.Fatalf("unexpected rules: %#v", rules)
+	}
+
+	err = client.UpdateAdvancedRedirect(context.Background(), "example.com", "www.example.com", &advancedRedirectRequest{
+		Active: "Y",
+		Rules: []AdvancedRedirectRule{
+			{HostPrefix: "shop", URLPattern: "https://shop.example.org/", Code: "302", Active: "Y"},
+			{Path: "/old/*", URLPattern: "https://example.org/new/", Code: "301", Active: "Y"},
+		},
+		Publish: "N",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := `{"active":"Y","rules":[` +
+		`{"host_prefix":"shop","path":"","url_pattern":"https://shop.example.org/","code":"302","active":"Y"},` +
+		`{"host_prefix":"","path":"/old/*","url_pattern":"https://example.org/new/","code":"301","active":"Y"}],"publish":"N"}`
+	if body != expected {
+		t.Fatalf("expected %s, got %s", expected, body)
+	}
+}
@@ -0,0 +1,256 @@
+package dyn
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration such as \"30s\" or \"5m\": %s", k, err))
+		return
+	}
+	if d <= 0 {
+		errors = append(errors, fmt.Errorf("%q must be a positive duration, got %q", k, value))
+	}
+	return
+}
+
+// validatePublishNotes checks that a publish_notes template parses and only
+// refers to the fields it is rendered with.
+func validatePublishNotes(v interface{}, k string) (ws []string, errors []error) {
+	tmpl, err := template.New(k).Parse(v.(string))
+	if err == nil {
+		err = tmpl.Execute(ioutil.Discard, publishNotesData{})
+	}
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid template: %s", k, err))
+	}
+	return
+}
+
+// validateHTTPURL checks that a string is an absolute http or https URL.
+func validateHTTPURL(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errors = append(errors, fmt.Errorf("%q must be an http or https URL, got %q", k, value))
+	}
+	return
+}
+
+// validateCIDR checks that a string is an IPv4 or IPv6 network in CIDR
+// notation.
+func validateCIDR(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a network such as \"2001:db8::/64\", got %q", k, value))
+	}
+	return
+}
+
+const maxTTL = 2147483647
+
+var (
+	hostnameLabelRe = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9_])?$`)
+	txtStringRe     = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+)
+
+// validateStringInSlice returns a function checking that a string is one of
+// valid.
+func validateStringInSlice(valid []string) func(interface{}, string) ([]string, []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		for _, s := range valid {
+			if value == s {
+				return
+			}
+		}
+		errors = append(errors, fmt.Errorf("%q must be one of %s, got %q", k, strings.Join(valid, ", "), value))
+		return
+	}
+}
+
+// validateIntInSlice returns a function checking that an int is one of
+// valid.
+func validateIntInSlice(valid []int) func(interface{}, string) ([]string, []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(int)
+		var names []string
+		for _, i := range valid {
+			if value == i {
+				return
+			}
+			names = append(names, strconv.Itoa(i))
+		}
+		errors = append(errors, fmt.Errorf("%q must be one of %s, got %d", k, strings.Join(names, ", "), value))
+		return
+	}
+}
+
+// validateIntAtLeast returns a function checking that an int is at least
+// min.
+func validateIntAtLeast(min int) func(interface{}, string) ([]string, []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if value := v.(int); value < min {
+			errors = append(errors, fmt.Errorf("%q must be at least %d, got %d", k, min, value))
+		}
+		return
+	}
+}
+
+// validateIntBetween returns a function checking that an int is between min
+// and max included.
+func validateIntBetween(min, max int) func(interface{}, string) ([]string, []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if value := v.(int); value < min || value > max {
+			errors = append(errors, fmt.Errorf("%q must be between %d and %d, got %d", k, min, max, value))
+		}
+		return
+	}
+}
+
+// validateTTL checks that a TTL is 0, meaning the zone default, or a number
+// of seconds in the range allowed by RFC 2181.
+func validateTTL(v interface{}, k string) (ws []string, errors []error) {
+	ttl := v.(int)
+	if ttl < 0 || ttl > maxTTL {
+		errors = append(errors, fmt.Errorf("%q must be between 0 and %d, got %d", k, maxTTL, ttl))
+	}
+	return
+}
+
+// validateRecordName checks the name of a record relative to its zone. The
+// leftmost label may be a "*" wildcard.
+func validateRecordName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+	name := strings.TrimPrefix(value, "*.")
+	if name == "*" {
+		return
+	}
+	if err := checkHostname(name); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid record name: %s", k, err))
+	}
+	return
+}
+
+// checkHostname checks the syntax of a domain name, which may be fully
+// qualified with a trailing dot.
+func checkHostname(name string) error {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return fmt.Errorf("empty domain name")
+	}
+	if len(name) > 253 {
+		return fmt.Errorf("%q is longer than 253 characters", name)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if !hostnameLabelRe.MatchString(label) {
+			return fmt.Errorf("%q has an invalid label %q", name, label)
+		}
+	}
+	return nil
+}
+
+// checkRecordValue checks the syntax of a record value for the given type.
+func checkRecordValue(recordType, value string) error {
+	switch recordType {
+	case "A":
+		ip := net.ParseIP(value)
+		if ip == nil || ip.To4() == nil || strings.Contains(value, ":") {
+			return fmt.Errorf("%q is not a valid IPv4 address", value)
+		}
+	case "AAAA":
+		ip := net.ParseIP(value)
+		if ip == nil || !strings.Contains(value, ":") {
+			return fmt.Errorf("%q is not a valid IPv6 address", value)
+		}
+	case "ALIAS", "CNAME", "NS", "PTR":
+		return checkHostname(value)
+	case "MX":
+		_, exchange, err := parseMXValue(value)
+		if err != nil {
+			return err
+		}
+		return checkHostname(exchange)
+	case "SRV":
+		_, _, _, target, err := parseSRVValue(value)
+		if err != nil {
+			return err
+		}
+		if target == "." {
+			return nil
+		}
+		return checkHostname(target)
+	case "SOA":
+		return checkHostname(value)
+	case "TXT", "SPF":
+		return checkTXTValue(value)
+	}
+	return nil
+}
+
+// parseMXValue splits an MX value such as "10 mx.example.com." into its
+// preference and exchange.
+func parseMXValue(value string) (int, string, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return 0, "", fmt.Errorf("MX value %q must be a preference followed by a host, such as \"10 mx.example.com.\"", value)
+	}
+	preference, err := strconv.Atoi(fields[0])
+	if err != nil || preference < 0 || preference > 65535 {
+		return 0, "", fmt.Errorf("MX value %q must start with a preference between 0 and 65535", value)
+	}
+	return preference, fields[1], nil
+}
+
+// parseSRVValue splits an SRV value such as "10 5 5060 sip.example.com."
+// into its priority, weight, port and target.
+func parseSRVValue(value string) (int, int, int, string, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return 0, 0, 0, "", fmt.Errorf("SRV value %q must be a priority, weight and port followed by a target, such as \"10 5 5060 sip.example.com.\"", value)
+	}
+	var numbers [3]int
+	for i, name := range []string{"priority", "weight", "port"} {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil || n < 0 || n > 65535 {
+			return 0, 0, 0, "", fmt.Errorf("SRV value %q must have a %s between 0 and 65535", value, name)
+		}
+		numbers[i] = n
+	}
+	return numbers[0], numbers[1], numbers[2], fields[3], nil
+}
+
+// checkTXTValue checks that a TXT value fits in DNS character-strings. Values
+// longer than 255 characters have to be split into several quoted strings.
+func checkTXTValue(value string) error {
+	if !strings.HasPrefix(value, `"`) {
+		if len(value) > 255 {
+			return fmt.Errorf("TXT value is %d characters long; values longer than 255 characters must be split into quoted strings such as \"part1\" \"part2\"", len(value))
+		}
+		return nil
+	}
+
+	for i, s := range txtStringRe.FindAllStringSubmatch(value, -1) {
+		if len(s[1]) > 255 {
+			return fmt.Errorf("TXT string %d is %d characters long, the maximum is 255", i+1, len(s[1]))
+		}
+	}
+	if strings.TrimSpace(txtStringRe.ReplaceAllString(value, "")) != "" {
+		return fmt.Errorf("TXT value %q mixes quoted and unquoted text", value)
+	}
+	return nil
+}
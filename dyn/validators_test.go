@@ -0,0 +1,150 @@
+package dyn
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTTL(t *testing.T) {
+	valid := []int{0, 30, 3600, 2147483647}
+	for _, v := range valid {
+		if _, errors := validateTTL(v, "ttl"); len(errors) != 0 {
+			t.Errorf("%d should be a valid TTL: %q", v, errors)
+		}
+	}
+
+	invalid := []int{-1, 2147483648}
+	for _, v := range invalid {
+		if _, errors := validateTTL(v, "ttl"); len(errors) == 0 {
+			t.Errorf("%d should be an invalid TTL", v)
+		}
+	}
+}
+
+func TestValidateRecordName(t *testing.T) {
+	valid := []string{"", "www", "*", "*.dev", "_dmarc", "_sip._tcp", "a-b.c-d", "example.com."}
+	for _, v := range valid {
+		if _, errors := validateRecordName(v, "name"); len(errors) != 0 {
+			t.Errorf("%q should be a valid name: %q", v, errors)
+		}
+	}
+
+	invalid := []string{"-www", "www-", "w w w", "www..dev", "dev.*", strings.Repeat("a", 64)}
+	for _, v := range invalid {
+		if _, errors := validateRecordName(v, "name"); len(errors) == 0 {
+			t.Errorf("%q should be an invalid name", v)
+		}
+	}
+}
+
+func TestCheckRecordValue(t *testing.T) {
+	cases := []struct {
+		recordType string
+		value      string
+		valid      bool
+	}{
+		{"A", "192.168.0.10", true},
+		{"A", "192.168.0.256", false},
+		{"A", "2001:db8::1", false},
+		{"A", "::ffff:192.168.0.10", false},
+		{"A", "terraform.io", false},
+		{"AAAA", "2001:db8::1", true},
+		{"AAAA", "2001:0db8:0000:0000:0000:0000:0000:0001", true},
+		{"AAAA", "192.168.0.10", false},
+		{"CNAME", "something.terraform.io", true},
+		{"CNAME", "something.terraform.io.", true},
+		{"CNAME", "not a host", false},
+		{"NS", "ns.terraform.io", true},
+		{"MX", "10 mx.terraform.io", true},
+		{"MX", "0 mx.terraform.io.", true},
+		{"MX", "mx.terraform.io", false},
+		{"MX", "10", false},
+		{"MX", "70000 mx.terraform.io", false},
+		{"MX", "10 mx.terraform.io extra", false},
+		{"TXT", "v=spf1 -all", true},
+		{"TXT", strings.Repeat("a", 255), true},
+		{"TXT", strings.Repeat("a", 256), false},
+		{"TXT", `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("b", 10) + `"`, true},
+		{"TXT", `"` + strings.Repeat("a", 256) + `"`, false},
+		{"TXT", `"part1" part2`, false},
+		{"SPF", "v=spf1 include:_spf.terraform.io ~all", true},
+	}
+
+	for _, tc := range cases {
+		err := checkRecordValue(tc.recordType, tc.value)
+		if tc.valid && err != nil {
+			t.Errorf("%s %q should be valid: %s", tc.recordType, tc.value, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s %q should be invalid", tc.recordType, tc.value)
+		}
+	}
+}
+
+func TestCheckCNAMEConflict(t *testing.T) {
+	fqdn := "www.conflict.terraform.io"
+	if err := checkCNAMEConflict(fqdn, "A", []string{"A", "AAAA"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := checkCNAMEConflict(fqdn, "CNAME", nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := checkCNAMEConflict(fqdn, "CNAME", []string{"A"}); err == nil {
+		t.Fatal("expected a CNAME next to an A record to conflict")
+	}
+	if err := checkCNAMEConflict(fqdn, "TXT", []string{"CNAME"}); err == nil {
+		t.Fatal("expected a TXT record next to a CNAME to conflict")
+	}
+}
+
+func TestValidatePublishNotes(t *testing.T) {
+	valid := []string{"", "CHG-1234", DefaultPublishNotes, "{{.Resource}} in {{.Zone}} by {{.DynUser}}"}
+	for _, v := range valid {
+		if _, errors := validatePublishNotes(v, "publish_notes"); len(errors) != 0 {
+			t.Errorf("%q should be a valid template: %q", v, errors)
+		}
+	}
+
+	invalid := []string{"{{.Resource", "{{.Address}}", "{{end}}"}
+	for _, v := range invalid {
+		if _, errors := validatePublishNotes(v, "publish_notes"); len(errors) == 0 {
+			t.Errorf("%q should be an invalid template", v)
+		}
+	}
+}
+
+func TestValidateIntInSlice(t *testing.T) {
+	validate := validateIntInSlice([]int{1024, 2048, 4096})
+	if _, errors := validate(2048, "bits"); len(errors) != 0 {
+		t.Errorf("2048 should be valid: %q", errors)
+	}
+	if _, errors := validate(512, "bits"); len(errors) == 0 {
+		t.Errorf("512 should be invalid")
+	}
+}
+
+func TestValidateHTTPURL(t *testing.T) {
+	for _, v := range []string{"http://example.com", "https://example.com/path?q=1"} {
+		if _, errors := validateHTTPURL(v, "url"); len(errors) != 0 {
+			t.Errorf("%q should be valid: %q", v, errors)
+		}
+	}
+	for _, v := range []string{"example.com", "ftp://example.com", "https://", "http//example.com"} {
+		if _, errors := validateHTTPURL(v, "url"); len(errors) == 0 {
+			t.Errorf("%q should be invalid", v)
+		}
+	}
+}
+
+func TestValidateCIDR(t *testing.T) {
+	for _, v := range []string{"2001:db8::/64", "192.168.0.0/24"} {
+		if _, errors := validateCIDR(v, "netmask"); len(errors) != 0 {
+			t.Errorf("%q should be valid: %q", v, errors)
+		}
+	}
+	for _, v := range []string{"2001:db8::", "192.168.0.0/33", "example.com/24", ""} {
+		if _, errors := validateCIDR(v, "netmask"); len(errors) == 0 {
+			t.Errorf("%q should be invalid", v)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package dyn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// discardTimeout bounds the clean up of a failed change, which runs even
+// when the context of the change itself is done.
+const discardTimeout = 1 * time.Minute
+
+// ZoneChange is a change staged on a zone that has not been published yet.
+type ZoneChange struct {
+	ID        int                    `json:"id"`
+	UserID    int                    `json:"user_id"`
+	Zone      string                 `json:"zone"`
+	FQDN      string                 `json:"fqdn"`
+	RDataType string                 `json:"rdata_type"`
+	RData     map[string]interface{} `json:"rdata"`
+	TTL       int                    `json:"ttl"`
+	Serial    int                    `json:"serial"`
+}
+
+// ZoneChangesResponse holds the data returned by a call to
+// "https://api.dynect.net/REST/ZoneChanges/ZONE_NAME".
+type ZoneChangesResponse struct {
+	dynect.ResponseBlock
+	Data []ZoneChange `json:"data"`
+}
+
+// GetZoneChanges lists the changes pending on a zone.
+func (c *Client) GetZoneChanges(ctx context.Context, zone string) ([]ZoneChange, error) {
+	var resp ZoneChangesResponse
+	if err := c.DoContext(ctx, "GET", "ZoneChanges/"+zone, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// HasPendingChange reports whether a change to the records of the given type
+// at fqdn is pending on zone.
+func (c *Client) HasPendingChange(ctx context.Context, zone, fqdn, recordType string) (bool, error) {
+	changes, err := c.GetZoneChanges(ctx, zone)
+	if err != nil {
+		return false, err
+	}
+	for _, change := range changes {
+		if strings.EqualFold(strings.TrimSuffix(change.FQDN, "."), strings.TrimSuffix(fqdn, ".")) && change.RDataType == recordType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DiscardZoneChanges discards the changes pending on a zone in the current
+// session.
+func (c *Client) DiscardZoneChanges(ctx context.Context, zone string) error {
+	return c.DoContext(ctx, "DELETE", "ZoneChanges/"+zone, nil, nil)
+}
+
+// Zone is a zone as returned by a call to
+// "https://api.dynect.net/REST/Zone/ZONE_NAME". Frozen is nil when Dyn does
+// not report whether the zone is frozen.
+type Zone struct {
+	dynect.ZoneDataBlock
+	Frozen *bool `json:"frozen,omitempty"`
+}
+
+// zoneResponse is dynect.ZoneResponse with the frozen state of the zone.
+type zoneResponse struct {
+	dynect.ResponseBlock
+	Data Zone `json:"data"`
+}
+
+// GetZone fetches a zone.
+func (c *Client) GetZone(ctx context.Context, zone string) (*Zone, error) {
+	var resp zoneResponse
+	if err := c.DoContext(ctx, "GET", "Zone/"+zone, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetZoneDefaultTTL fetches the default TTL of a zone, which is the TTL of
+// its SOA record. Records created without a TTL inherit it.
+func (c *Client) GetZoneDefaultTTL(ctx context.Context, zone string) (int, error) {
+	var resp struct {
+		Data []zoneRecordDetail `json:"data"`
+	}
+	if err := c.DoContext(ctx, "GET", "SOARecord/"+zone+"/"+zone+"/?detail=Y", nil, &resp); err != nil {
+		return 0, err
+	}
+	if len(resp.Data) == 0 {
+		return 0, fmt.Errorf("Dyn zone %s has no SOA record", zone)
+	}
+	return resp.Data[0].TTL, nil
+}
+
+// FreezeZone freezes a zone, so that it cannot be changed until it is
+// thawed.
+func (c *Client) FreezeZone(ctx context.Context, zone string) error {
+	data := map[string]bool{"freeze": true}
+	return c.DoContext(ctx, "PUT", "Zone/"+zone, data, nil)
+}
+
+// ThawZone thaws a frozen zone.
+func (c *Client) ThawZone(ctx context.Context, zone string) error {
+	data := map[string]bool{"thaw": true}
+	return c.DoContext(ctx, "PUT", "Zone/"+zone, data, nil)
+}
+
+// zoneFrozenError explains an error caused by a change to a frozen zone, and
+// returns any other error unchanged.
+func zoneFrozenError(zone string, err error) error {
+	if err == nil || !strings.Contains(strings.ToLower(err.Error()), "frozen") {
+		return err
+	}
+	return fmt.Errorf("Dyn zone %s is frozen: thaw it, or wait for the change freeze to end, before changing it: %w", zone, err)
+}
+
+// ZoneNote is an entry of the publish history of a zone.
+type ZoneNote struct {
+	Zone      string      `json:"zone"`
+	Serial    looseString `json:"serial"`
+	Type      string      `json:"type"`
+	UserName  string      `json:"user_name"`
+	Timestamp looseString `json:"timestamp"`
+	Note      string      `json:"note"`
+}
+
+// ZoneNotesResponse holds the data returned by a call to
+// "https://api.dynect.net/REST/ZoneNoteReport/".
+type ZoneNotesResponse struct {
+	dynect.ResponseBlock
+	Data []ZoneNote `json:"data"`
+}
+
+// zoneNotesRequest is the body of a ZoneNoteReport request.
+type zoneNotesRequest struct {
+	Zone   string `json:"zone"`
+	Limit  int    `json:"limit,omitempty"`
+	Offset int    `json:"offset,omitempty"`
+}
+
+// GetZoneNotes lists the publish history of a zone, most recent first,
+// skipping offset entries and returning at most limit of them.
+func (c *Client) GetZoneNotes(ctx context.Context, zone string, limit, offset int) ([]ZoneNote, error) {
+	req := zoneNotesRequest{
+		Zone:   zone,
+		Limit:  limit,
+		Offset: offset,
+	}
+	var resp ZoneNotesResponse
+	if err := c.DoContext(ctx, "POST", "ZoneNoteReport", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// looseString decodes a JSON string or number, as the Dyn API returns some
+// numeric fields as either.
+type looseString string
+
+func (s *looseString) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case string:
+		*s = looseString(v)
+	case json.Number:
+		*s = looseString(v.String())
+	case nil:
+		*s = ""
+	default:
+		return fmt.Errorf("expected a string or a number, got %s", b)
+	}
+	return nil
+}
+
+// Ways of handling unpublished changes that were not made by Terraform.
+const (
+	ForeignChangesFail    = "fail"
+	ForeignChangesWarn    = "warn"
+	ForeignChangesProceed = "proceed"
+)
+
+// checkForeignZoneChanges lists the changes pending on a zone before
+// Terraform makes its own, and fails, warns or carries on according to the
+// ForeignChanges setting of the client. It returns the changes it carried on
+// with.
+func checkForeignZoneChanges(ctx context.Context, client *Client, zone string) ([]ZoneChange, error) {
+	changes, err := client.GetZoneChanges(ctx, zone)
+	if isNotFound(err) {
+		// a zone that does not exist yet, such as one created from a zone
+		// file, has nothing pending
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list pending changes of Dyn zone %s: %s", zone, err)
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	list := describeZoneChanges(changes)
+	switch client.ForeignChanges {
+	case ForeignChangesFail:
+		return nil, fmt.Errorf("Dyn zone %s has %d unpublished changes that were not made by Terraform; publish or discard them first, or set foreign_changes on the provider:\n  - %s", zone, len(changes), list)
+	case ForeignChangesProceed:
+		log.Printf("[INFO] Dyn zone %s has %d unpublished changes that will be published along with Terraform's:\n  - %s", zone, len(changes), list)
+		return changes, nil
+	}
+	log.Printf("[WARN] Dyn zone %s has %d unpublished changes that were not made by Terraform and will be published along with Terraform's:\n  - %s", zone, len(changes), list)
+	return changes, nil
+}
+
+// describeZoneChanges renders pending changes as a list for logs and errors.
+func describeZoneChanges(changes []ZoneChange) string {
+	descriptions := make([]string, len(changes))
+	for i, c := range changes {
+		descriptions[i] = describeZoneChange(c)
+	}
+	return strings.Join(descriptions, "\n  - ")
+}
+
+// describeZoneChange renders a pending change for logs and errors.
+func describeZoneChange(c ZoneChange) string {
+	return fmt.Sprintf("%s %s (ttl %d, user %d, change %d)", c.RDataType, c.FQDN, c.TTL, c.UserID, c.ID)
+}
+
+// autoPublishes reports whether changes to zone are published as soon as
+// they are staged.
+func (c *Client) autoPublishes(zone string) bool {
+	if !c.AutoPublish {
+		return false
+	}
+	for _, z := range c.ManualPublishZones {
+		if strings.EqualFold(strings.TrimSuffix(z, "."), strings.TrimSuffix(zone, ".")) {
+			return false
+		}
+	}
+	return true
+}
+
+// publishZoneChanges stages changes on a zone with stage, then publishes
+// them. Nothing is started while the zone has unpublished changes, unless the
+// provider is configured to publish them anyway, and if staging or publishing
+// fails the changes pending in the session are discarded, so that no later
+// publish ships a half-made change. When other changes were already pending,
+// nothing is discarded, as that would discard them too: the error lists what
+// is left pending instead.
+//
+// The publish carries notes into the history of the zone. A publish that is
+// still running when ctx is done is left alone: its job is returned in a
+// JobError so that it can be waited on later.
+//
+// Zones that are published manually only have their changes staged. Other
+// changes are expected to be pending on them, and nothing is discarded when
+// staging fails, as pending changes may be under review.
+func publishZoneChanges(ctx context.Context, client *Client, zone, notes string, stage func() error) error {
+	if !client.autoPublishes(zone) {
+		if err := zoneFrozenError(zone, stage()); err != nil {
+			log.Printf("[WARN] Dyn zone %s is published manually, review its pending changes before publishing it", zone)
+			return err
+		}
+		log.Printf("[INFO] Dyn zone %s is published manually, leaving the changes pending", zone)
+		return nil
+	}
+
+	foreign, err := checkForeignZoneChanges(ctx, client, zone)
+	if err != nil {
+		return err
+	}
+
+	if err := zoneFrozenError(zone, stage()); err != nil {
+		return abandonZoneChanges(client, zone, foreign, err)
+	}
+
+	if err := client.PublishZone(ctx, zone, notes); err != nil {
+		err = zoneFrozenError(zone, fmt.Errorf("Failed to publish Dyn zone: %w", err))
+		if pendingJobID(err) != 0 {
+			return err
+		}
+		return abandonZoneChanges(client, zone, foreign, err)
+	}
+
+	return nil
+}
+
+// abandonZoneChanges handles the changes pending on a zone after cause made
+// a change fail. They are discarded when they were all staged by Terraform,
+// and left pending for review otherwise, as discarding them would discard
+// the foreign changes that were pending before. It returns cause.
+func abandonZoneChanges(client *Client, zone string, foreign []ZoneChange, cause error) error {
+	if len(foreign) == 0 {
+		return discardZoneChanges(client, zone, cause)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), discardTimeout)
+	defer cancel()
+
+	pending, err := client.GetZoneChanges(ctx, zone)
+	if err != nil {
+		pending = foreign
+	}
+	return fmt.Errorf("%w (Dyn zone %s had changes that were not made by Terraform pending, so nothing was discarded; review these pending changes, then publish or discard them:\n  - %s)", cause, zone, describeZoneChanges(pending))
+}
+
+// discardZoneChanges discards the changes pending in the session after cause
+// made a change fail, and returns cause.
+func discardZoneChanges(client *Client, zone string, cause error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), discardTimeout)
+	defer cancel()
+
+	log.Printf("[WARN] Discarding pending changes of Dyn zone %s: %s", zone, cause)
+	if err := client.DiscardZoneChanges(ctx, zone); err != nil {
+		return fmt.Errorf("%w (discarding the pending changes of Dyn zone %s also failed, they must be discarded before the zone is published again: %s)", cause, zone, err)
+	}
+	return cause
+}
@@ -0,0 +1,155 @@
+package dyn
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// zoneExportLine is a record of an exported zone, rendered in master file
+// syntax and keyed for sorting.
+type zoneExportLine struct {
+	name  string
+	typ   string
+	rdata string
+	ttl   int
+}
+
+// renderZoneFile renders the records of a zone as an RFC 1035 master file.
+// The SOA record comes first, then the records sorted by name, type and
+// rdata, so that successive exports of an unchanged zone are identical.
+// Records of types that cannot be rendered are listed as comments at the
+// end. It returns the file and the number of records it holds.
+func renderZoneFile(zone string, records []zoneRecordDetail) (string, int) {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+
+	var lines []zoneExportLine
+	var skipped []string
+	defaultTTL := 0
+	for _, r := range records {
+		rdata, err := zoneFileRData(r)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("; %s %s: %s", strings.TrimSuffix(r.FQDN, "."), r.RecordType, err))
+			continue
+		}
+		if r.RecordType == "SOA" {
+			defaultTTL = r.TTL
+		}
+		lines = append(lines, zoneExportLine{
+			name:  relativeRecordName(r.FQDN, zone),
+			typ:   r.RecordType,
+			rdata: rdata,
+			ttl:   r.TTL,
+		})
+	}
+	if defaultTTL == 0 {
+		defaultTTL = 3600
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		if (a.typ == "SOA") != (b.typ == "SOA") {
+			return a.typ == "SOA"
+		}
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if a.typ != b.typ {
+			return a.typ < b.typ
+		}
+		if a.rdata != b.rdata {
+			return a.rdata < b.rdata
+		}
+		return a.ttl < b.ttl
+	})
+	sort.Strings(skipped)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "$ORIGIN %s.\n", zone)
+	fmt.Fprintf(&buf, "$TTL %d\n", defaultTTL)
+	for _, l := range lines {
+		owner := l.name
+		if owner == "" {
+			owner = "@"
+		}
+		ttl := ""
+		if l.ttl != defaultTTL {
+			ttl = strconv.Itoa(l.ttl)
+		}
+		fmt.Fprintf(&buf, "%s\t%s\tIN\t%s\t%s\n", owner, ttl, l.typ, l.rdata)
+	}
+	if len(skipped) > 0 {
+		buf.WriteString("; records that cannot be exported:\n")
+		for _, s := range skipped {
+			buf.WriteString(s + "\n")
+		}
+	}
+	return buf.String(), len(lines)
+}
+
+// zoneFileRData renders the rdata of a record in master file syntax, with
+// domain names fully qualified and character strings quoted.
+func zoneFileRData(r zoneRecordDetail) (string, error) {
+	rdata := r.RData
+	switch r.RecordType {
+	case "ALIAS", "CNAME", "DNAME", "NS", "PTR":
+		value, err := recordValue(r.RecordType, rdata.DataBlock)
+		if err != nil {
+			return "", err
+		}
+		return zoneFileName(value), nil
+	case "MX":
+		return fmt.Sprintf("%d %s", rdata.Preference, zoneFileName(rdata.Exchange)), nil
+	case "KX":
+		return fmt.Sprintf("%d %s", rdata.Preference, zoneFileName(rdata.Exchange)), nil
+	case "SRV":
+		return fmt.Sprintf("%d %s %s %s", rdata.Priority, rdata.Weight, rdata.Port, zoneFileName(rdata.Target)), nil
+	case "SOA":
+		return fmt.Sprintf("%s %s %s %s %s %s %s", zoneFileName(rdata.MName), zoneFileName(rdata.RName),
+			rdata.Serial, rdata.Refresh, rdata.Retry, rdata.Expire, rdata.Minimum), nil
+	case "TXT", "SPF":
+		return zoneFileStrings(rdata.TxtData), nil
+	case "NAPTR":
+		return fmt.Sprintf("%s %d %s %s %s %s", rdata.Order, rdata.Preference, zoneFileString(rdata.Flags),
+			zoneFileString(rdata.Services), zoneFileString(rdata.Regexp), zoneFileName(rdata.Replacement)), nil
+	case "PX":
+		return fmt.Sprintf("%d %s %s", rdata.Preference, zoneFileName(rdata.Map822), zoneFileName(rdata.MapX400)), nil
+	case "RP":
+		return fmt.Sprintf("%s %s", zoneFileName(rdata.Mbox), zoneFileName(rdata.TxtDName)), nil
+	}
+	return recordValue(r.RecordType, rdata.DataBlock)
+}
+
+// zoneFileName makes a domain name fully qualified.
+func zoneFileName(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// zoneFileString quotes a character string.
+func zoneFileString(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + s + `"`
+}
+
+// zoneFileStrings quotes the text of a TXT record, which Dyn returns either
+// as a single string or as quoted strings already, splitting it into
+// character strings of at most 255 bytes.
+func zoneFileStrings(text string) string {
+	if strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`) && len(text) > 1 {
+		return text
+	}
+
+	var parts []string
+	for len(text) > 255 {
+		parts = append(parts, zoneFileString(text[:255]))
+		text = text[255:]
+	}
+	parts = append(parts, zoneFileString(text))
+	return strings.Join(parts, " ")
+}
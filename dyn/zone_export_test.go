@@ -0,0 +1,69 @@
+package dyn
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderZoneFile(t *testing.T) {
+	var records []zoneRecordDetail
+	err := json.Unmarshal([]byte(`[
+		{"zone": "example.com", "fqdn": "www.example.com", "record_type": "TXT", "ttl": 300, "rdata": {"txtdata": "say \"hi\""}},
+		{"zone": "example.com", "fqdn": "www.example.com", "record_type": "A", "ttl": 300, "rdata": {"address": "192.168.0.11"}},
+		{"zone": "example.com", "fqdn": "www.example.com", "record_type": "A", "ttl": 300, "rdata": {"address": "192.168.0.10"}},
+		{"zone": "example.com", "fqdn": "example.com", "record_type": "MX", "ttl": 3600, "rdata": {"preference": "10", "exchange": "mx.example.com"}},
+		{"zone": "example.com", "fqdn": "example.com", "record_type": "DS", "ttl": 3600, "rdata": {"keytag": 12345, "algorithm": 8, "digtype": 2, "digest": "ABCD"}},
+		{"zone": "example.com", "fqdn": "_sip._tcp.example.com", "record_type": "SRV", "ttl": 3600, "rdata": {"priority": 0, "weight": 5, "port": 5060, "target": "sip.example.com."}},
+		{"zone": "example.com", "fqdn": "example.com", "record_type": "SOA", "ttl": 3600, "rdata": {"mname": "ns1.p01.dynect.net.", "rname": "hostmaster.example.com.", "serial": 42, "refresh": 3600, "retry": 600, "expire": 604800, "minimum": 1800}},
+		{"zone": "example.com", "fqdn": "example.com", "record_type": "CAA", "ttl": 3600, "rdata": {}}
+	]`), &records)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	file, count := renderZoneFile("example.com", records)
+
+	expected := strings.Join([]string{
+		"$ORIGIN example.com.",
+		"$TTL 3600",
+		"@\t\tIN\tSOA\tns1.p01.dynect.net. hostmaster.example.com. 42 3600 600 604800 1800",
+		"@\t\tIN\tDS\t12345 8 2 ABCD",
+		"@\t\tIN\tMX\t10 mx.example.com.",
+		"_sip._tcp\t\tIN\tSRV\t0 5 5060 sip.example.com.",
+		"www\t300\tIN\tA\t192.168.0.10",
+		"www\t300\tIN\tA\t192.168.0.11",
+		"www\t300\tIN\tTXT\t\"say \\\"hi\\\"\"",
+		"; records that cannot be exported:",
+		"; example.com CAA: Invalid Dyn record type: CAA",
+		"",
+	}, "\n")
+	if file != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, file)
+	}
+	if count != 7 {
+		t.Fatalf("expected 7 records, got %d", count)
+	}
+
+	// the order of the records returned by Dyn does not matter
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	if again, _ := renderZoneFile("example.com", records); again != file {
+		t.Fatalf("export depends on the order of the records:\n%s", again)
+	}
+}
+
+func TestZoneFileStrings(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	cases := map[string]string{
+		"v=spf1 -all":       `"v=spf1 -all"`,
+		`"already" "split"`: `"already" "split"`,
+		long:                `"` + long[:255] + `" "` + long[255:] + `"`,
+	}
+	for text, expected := range cases {
+		if actual := zoneFileStrings(text); actual != expected {
+			t.Errorf("zoneFileStrings(%q): expected %q, got %q", text, expected, actual)
+		}
+	}
+}
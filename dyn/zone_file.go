@@ -0,0 +1,370 @@
+package dyn
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// zoneFileRecordTypes are the record types of a zone file that are compared
+// with the live zone. Records of other types, and the SOA record, which Dyn
+// maintains, are uploaded but not checked for drift.
+var zoneFileRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"ALIAS": true,
+	"CNAME": true,
+	"MX":    true,
+	"NS":    true,
+	"PTR":   true,
+	"SPF":   true,
+	"SRV":   true,
+	"TXT":   true,
+}
+
+// zoneFileFilter leaves out of drift detection the apex NS records, which
+// Dyn sets itself when a zone file is uploaded.
+var zoneFileFilter = &recordFilter{ApexNS: true}
+
+// zoneFileRequest is the body of a ZoneFile request.
+type zoneFileRequest struct {
+	File string `json:"file"`
+}
+
+// UploadZoneFile creates a zone from a BIND zone file, and waits for the
+// job doing so to complete.
+func (c *Client) UploadZoneFile(ctx context.Context, zone, file string) error {
+	return c.DoContext(ctx, "POST", "ZoneFile/"+zone+"/", zoneFileRequest{File: file}, nil)
+}
+
+// DeleteZone deletes a zone and all its records.
+func (c *Client) DeleteZone(ctx context.Context, zone string) error {
+	return c.DoContext(ctx, "DELETE", "Zone/"+zone+"/", nil, nil)
+}
+
+// parseZoneFile parses a BIND zone file for zone into records, with values
+// in the form dyn_record takes them. Records of types outside
+// zoneFileRecordTypes are skipped.
+func parseZoneFile(file, zone string) ([]dynect.Record, error) {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	p := &zoneFileParser{origin: zone + "."}
+
+	entries, err := splitZoneFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []dynect.Record
+	for _, e := range entries {
+		r, err := p.parseEntry(e)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", e.line, err)
+		}
+		if r == nil || !zoneFileRecordTypes[r.Type] {
+			continue
+		}
+
+		fqdn := strings.TrimSuffix(r.FQDN, ".")
+		if fqdn != zone && !strings.HasSuffix(fqdn, "."+zone) {
+			return nil, fmt.Errorf("line %d: %s is outside of zone %s", e.line, fqdn, zone)
+		}
+		r.Zone = zone
+		r.FQDN = fqdn
+		r.Name = relativeRecordName(fqdn, zone)
+		records = append(records, *r)
+	}
+	return records, nil
+}
+
+// zoneFileEntry is a directive or a record of a zone file, with parentheses
+// joined and comments removed.
+type zoneFileEntry struct {
+	line   int
+	indent bool
+	tokens []string
+}
+
+// splitZoneFile splits a zone file into entries.
+func splitZoneFile(file string) ([]zoneFileEntry, error) {
+	var entries []zoneFileEntry
+	var current *zoneFileEntry
+	depth := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(file))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		tokens, opened, err := tokenizeZoneFileLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n, err)
+		}
+
+		if current == nil {
+			if len(tokens) == 0 && opened == 0 {
+				continue
+			}
+			current = &zoneFileEntry{
+				line:   n,
+				indent: len(line) > 0 && unicode.IsSpace(rune(line[0])),
+			}
+		}
+		current.tokens = append(current.tokens, tokens...)
+
+		depth += opened
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", n)
+		}
+		if depth == 0 {
+			entries = append(entries, *current)
+			current = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.line)
+	}
+	return entries, nil
+}
+
+// tokenizeZoneFileLine splits a line into tokens, keeping quoted strings
+// with their quotes, and returns how many more parentheses it opens than it
+// closes.
+func tokenizeZoneFileLine(line string) ([]string, int, error) {
+	var tokens []string
+	opened := 0
+
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ';':
+			return tokens, opened, nil
+		case c == '(':
+			opened++
+			i++
+		case c == ')':
+			opened--
+			i++
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(line) && line[j] != '"'; j++ {
+				if line[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(line) {
+				return nil, 0, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, line[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(line) && !unicode.IsSpace(rune(line[j])) && !strings.ContainsRune(";()\"", rune(line[j])); j++ {
+			}
+			tokens = append(tokens, line[i:j])
+			i = j
+		}
+	}
+	return tokens, opened, nil
+}
+
+// zoneFileParser holds the state carried from one entry of a zone file to
+// the next.
+type zoneFileParser struct {
+	origin     string
+	defaultTTL int
+	lastTTL    int
+	lastOwner  string
+}
+
+// parseEntry parses a directive, which returns no record, or a record.
+func (p *zoneFileParser) parseEntry(e zoneFileEntry) (*dynect.Record, error) {
+	tokens := e.tokens
+
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("$ORIGIN takes a single domain name")
+		}
+		p.origin = p.absolute(tokens[1])
+		return nil, nil
+	case "$TTL":
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("$TTL takes a single TTL")
+		}
+		ttl, err := parseZoneFileTTL(tokens[1])
+		if err != nil {
+			return nil, err
+		}
+		p.defaultTTL = ttl
+		return nil, nil
+	case "$INCLUDE", "$GENERATE":
+		return nil, fmt.Errorf("%s is not supported", tokens[0])
+	}
+
+	owner := p.lastOwner
+	if !e.indent {
+		owner = p.absolute(tokens[0])
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		return nil, fmt.Errorf("record has no owner name")
+	}
+	p.lastOwner = owner
+
+	ttl := -1
+	for len(tokens) > 0 {
+		if ttl < 0 && startsWithDigit(tokens[0]) {
+			v, err := parseZoneFileTTL(tokens[0])
+			if err != nil {
+				return nil, err
+			}
+			ttl = v
+		} else if class := strings.ToUpper(tokens[0]); class == "IN" || class == "CH" || class == "HS" {
+			if class != "IN" {
+				return nil, fmt.Errorf("class %s is not supported", class)
+			}
+		} else {
+			break
+		}
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("record has no type")
+	}
+
+	switch {
+	case ttl >= 0:
+		p.lastTTL = ttl
+	case p.defaultTTL > 0:
+		ttl = p.defaultTTL
+	case p.lastTTL > 0:
+		ttl = p.lastTTL
+	default:
+		ttl = 3600
+	}
+
+	recordType := strings.ToUpper(tokens[0])
+	rdata := tokens[1:]
+	value, err := p.recordValue(recordType, rdata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s record: %s", recordType, err)
+	}
+
+	return &dynect.Record{
+		FQDN:  owner,
+		Type:  recordType,
+		TTL:   strconv.Itoa(ttl),
+		Value: value,
+	}, nil
+}
+
+// recordValue renders the rdata of a record in the form dyn_record takes it.
+func (p *zoneFileParser) recordValue(recordType string, rdata []string) (string, error) {
+	want := map[string]int{
+		"A": 1, "AAAA": 1, "ALIAS": 1, "CNAME": 1, "NS": 1, "PTR": 1,
+		"MX": 2, "SRV": 4, "SOA": 7,
+	}
+	if n, ok := want[recordType]; ok && len(rdata) != n {
+		return "", fmt.Errorf("expected %d fields, got %d", n, len(rdata))
+	}
+
+	switch recordType {
+	case "A", "AAAA":
+		return rdata[0], nil
+	case "ALIAS", "CNAME", "NS", "PTR":
+		return p.absolute(rdata[0]), nil
+	case "MX":
+		return fmt.Sprintf("%s %s", rdata[0], p.absolute(rdata[1])), nil
+	case "SRV":
+		return fmt.Sprintf("%s %s %s %s", rdata[0], rdata[1], rdata[2], p.absolute(rdata[3])), nil
+	case "SOA":
+		// dyn_record exposes the responsible mailbox of the SOA record
+		return p.absolute(rdata[1]), nil
+	case "TXT", "SPF":
+		if len(rdata) == 0 {
+			return "", fmt.Errorf("expected at least one string")
+		}
+		return strings.Join(rdata, " "), nil
+	}
+	return strings.Join(rdata, " "), nil
+}
+
+// absolute makes a domain name of the zone file fully qualified.
+func (p *zoneFileParser) absolute(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return p.origin
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + p.origin
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// parseZoneFileTTL parses a TTL in seconds or with BIND units, as in "1h30m".
+func parseZoneFileTTL(s string) (int, error) {
+	if v, err := strconv.Atoi(s); err == nil {
+		return v, nil
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, n, digits := 0, 0, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			n = n*10 + int(c-'0')
+			digits = true
+			continue
+		}
+		unit, ok := units[byte(unicode.ToLower(rune(c)))]
+		if !ok || !digits {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		total += n * unit
+		n, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	return total, nil
+}
+
+// zoneRecordsSHA256 summarises the records of a zone that a zone file is
+// compared against, so that drift shows up as a change of the digest.
+func zoneRecordsSHA256(records []dynect.Record) string {
+	var lines []string
+	for _, r := range zoneFileRecords(records) {
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", strings.ToLower(r.Name), r.Type, r.TTL, normalizeRecordValue(r.Type, r.Value)))
+	}
+	sort.Strings(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// zoneFileRecords keeps the records that a zone file is compared against.
+func zoneFileRecords(records []dynect.Record) []dynect.Record {
+	var kept []dynect.Record
+	for _, r := range records {
+		if zoneFileRecordTypes[r.Type] && !zoneFileFilter.Excluded(r.Type, r.Name) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,168 @@
+package dyn
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const testZoneFile = `
+$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.p01.dynect.net. hostmaster.example.com. (
+		2018101901 ; serial
+		3600       ; refresh
+		600        ; retry
+		604800     ; expire
+		1800 )     ; minimum
+	IN	NS	ns1.p01.dynect.net.
+	IN	MX	10 mx
+www	300	IN	A	192.168.0.10
+	300	IN	A	192.168.0.11
+WWW6	IN	300	AAAA	2001:0db8::1 ; comment
+mail	CNAME	www.example.com.
+@	TXT	"v=spf1 include:_spf.example.com ~all"
+long	TXT	"part one" "part two"
+_sip._tcp	SRV	10 5 5060 sip
+key	3600	DNSKEY	257 3 8 AwEAAa
+$ORIGIN dev.example.com.
+api	1d	A	192.168.1.10
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := parseZoneFile(testZoneFile, "example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var actual []string
+	for _, r := range records {
+		actual = append(actual, fmt.Sprintf("%s|%s|%s|%s", r.Name, r.Type, r.TTL, r.Value))
+	}
+
+	expected := []string{
+		"|NS|3600|ns1.p01.dynect.net.",
+		"|MX|3600|10 mx.example.com.",
+		"www|A|300|192.168.0.10",
+		"www|A|300|192.168.0.11",
+		"www6|AAAA|300|2001:0db8::1",
+		"mail|CNAME|3600|www.example.com.",
+		`|TXT|3600|"v=spf1 include:_spf.example.com ~all"`,
+		`long|TXT|3600|"part one" "part two"`,
+		"_sip._tcp|SRV|3600|10 5 5060 sip.example.com.",
+		"api.dev|A|86400|192.168.1.10",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestParseZoneFile_invalid(t *testing.T) {
+	cases := map[string]string{
+		"www A 192.168.0.10\n(":           "unbalanced parentheses",
+		"www TXT \"unterminated":          "unterminated string",
+		"www CH A 192.168.0.10":           "class CH is not supported",
+		"$INCLUDE other.zone":             "$INCLUDE is not supported",
+		"www MX mx.example.com.":          "expected 2 fields",
+		"www.example.org. A 192.168.0.10": "outside of zone",
+		"\tA 192.168.0.10":                "no owner name",
+		"www 1x A 192.168.0.10":           "invalid TTL",
+	}
+
+	for file, expected := range cases {
+		_, err := parseZoneFile(file, "example.com")
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%q: expected an error containing %q, got: %v", file, expected, err)
+		}
+	}
+}
+
+func TestParseZoneFileTTL(t *testing.T) {
+	cases := map[string]int{
+		"300":   300,
+		"1h":    3600,
+		"1h30m": 5400,
+		"2D":    172800,
+		"1w":    604800,
+	}
+	for s, expected := range cases {
+		actual, err := parseZoneFileTTL(s)
+		if err != nil {
+			t.Fatalf("%q: err: %s", s, err)
+		}
+		if actual != expected {
+			t.Errorf("%q: expected %d, got %d", s, expected, actual)
+		}
+	}
+}
+
+func TestZoneRecordsSHA256(t *testing.T) {
+	records, err := parseZoneFile(testZoneFile, "example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// the same records written differently, without the records Dyn
+	// manages
+	equivalent, err := parseZoneFile(`
+$TTL 3600
+example.com.	MX	10 MX.example.com.
+www	300	A	192.168.0.11
+www	300	A	192.168.0.10
+www6	300	AAAA	2001:db8::1
+mail	CNAME	www
+@	TXT	("v=spf1 include:_spf.example.com ~all")
+long	TXT	"part one" "part two"
+_sip._tcp	SRV	10 5 5060 sip.example.com.
+api.dev	86400	A	192.168.1.10
+`, "example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if zoneRecordsSHA256(records) != zoneRecordsSHA256(equivalent) {
+		t.Fatal("expected equivalent zone files to have the same digest")
+	}
+
+	equivalent[0].TTL = "60"
+	if zoneRecordsSHA256(records) == zoneRecordsSHA256(equivalent) {
+		t.Fatal("expected a change of TTL to change the digest")
+	}
+}
+
+func TestResourceDynZoneFileCreate_publishFailure(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/REST/"))
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/REST/ZoneChanges/example.com":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "PUT" && r.URL.Path == "/REST/Zone/example.com":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status": "failure", "msgs": [{"INFO": "publish failed", "LVL": "ERROR"}]}`)
+		default:
+			fmt.Fprint(w, `{"status": "success", "data": {}}`)
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceDynZoneFile().Schema, map[string]interface{}{
+		"zone":      "example.com",
+		"zone_file": testZoneFile,
+	})
+	if err := resourceDynZoneFileCreate(d, testClient(server)); err == nil {
+		t.Fatal("expected a publish error")
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected no ID after a failed publish, got %q", d.Id())
+	}
+
+	expected := "GET ZoneChanges/example.com,POST ZoneFile/example.com/,PUT Zone/example.com,DELETE ZoneChanges/example.com,DELETE Zone/example.com/"
+	if actual := strings.Join(requests, ","); actual != expected {
+		t.Fatalf("expected requests %q, got %q", expected, actual)
+	}
+}
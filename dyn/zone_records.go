@@ -0,0 +1,285 @@
+package dyn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+// allRecordsDetailResponse holds the data returned by a call to
+// "https://api.dynect.net/REST/AllRecord/ZONE_NAME?detail=Y", which groups
+// the records of the zone by type, as in "a_records".
+type allRecordsDetailResponse struct {
+	dynect.ResponseBlock
+	Data map[string][]zoneRecordDetail `json:"data"`
+}
+
+// zoneRecordDetail is a record as listed by AllRecord, with the whole of its
+// rdata.
+type zoneRecordDetail struct {
+	FQDN       string          `json:"fqdn"`
+	RecordID   int             `json:"record_id"`
+	RecordType string          `json:"record_type"`
+	TTL        int             `json:"ttl"`
+	Zone       string          `json:"zone"`
+	RData      zoneRecordRData `json:"rdata"`
+}
+
+// zoneRecordRData is the rdata of a record, with the SOA fields that
+// dynect.DataBlock leaves out.
+type zoneRecordRData struct {
+	dynect.DataBlock
+	MName   string `json:"mname"`
+	Serial  string `json:"serial"`
+	Refresh string `json:"refresh"`
+	Retry   string `json:"retry"`
+	Expire  string `json:"expire"`
+	Minimum string `json:"minimum"`
+}
+
+// UnmarshalJSON decodes rdata whether Dyn sends its numeric fields, such as
+// the algorithm of a DNSKEY record, as numbers or as strings, so that a
+// single record cannot prevent a zone from being listed.
+func (r *zoneRecordRData) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var fields map[string]interface{}
+	if err := dec.Decode(&fields); err != nil {
+		return err
+	}
+
+	for k, v := range fields {
+		intField := k == "preference" || k == "priority"
+		switch v := v.(type) {
+		case json.Number:
+			if !intField {
+				fields[k] = v.String()
+			}
+		case string:
+			if intField {
+				if n, err := strconv.Atoi(v); err == nil {
+					fields[k] = n
+				} else {
+					delete(fields, k)
+				}
+			}
+		}
+	}
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	type plain zoneRecordRData
+	return json.Unmarshal(normalized, (*plain)(r))
+}
+
+// getZoneRecordDetails lists every record of a zone with its whole rdata.
+func (c *Client) getZoneRecordDetails(ctx context.Context, zone string) ([]zoneRecordDetail, error) {
+	var resp allRecordsDetailResponse
+	if err := c.DoContext(ctx, "GET", "AllRecord/"+zone+"?detail=Y", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	var records []zoneRecordDetail
+	for _, group := range resp.Data {
+		records = append(records, group...)
+	}
+	return records, nil
+}
+
+// GetZoneRecords lists every record of a zone. Records of a type the
+// provider cannot represent are skipped.
+func (c *Client) GetZoneRecords(ctx context.Context, zone string) ([]dynect.Record, error) {
+	details, err := c.getZoneRecordDetails(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []dynect.Record
+	for _, r := range details {
+		value, err := recordValue(r.RecordType, r.RData.DataBlock)
+		if err != nil {
+			log.Printf("[DEBUG] Skipping Dyn record %s %s: %s", r.RecordType, r.FQDN, err)
+			continue
+		}
+		records = append(records, dynect.Record{
+			ID:    strconv.Itoa(r.RecordID),
+			Zone:  r.Zone,
+			FQDN:  r.FQDN,
+			Name:  relativeRecordName(r.FQDN, r.Zone),
+			Type:  r.RecordType,
+			TTL:   strconv.Itoa(r.TTL),
+			Value: value,
+		})
+	}
+	return records, nil
+}
+
+// recordSetRequest is the body of a request replacing all the records of a
+// type at a node, keyed by the type as in "ARecords".
+type recordSetRequest map[string][]dynect.RecordRequest
+
+// ReplaceRecordSet stages the replacement of all the records of a type at
+// fqdn with records.
+func (c *Client) ReplaceRecordSet(ctx context.Context, zone, fqdn, recordType string, records []dynect.Record) error {
+	requests := make([]dynect.RecordRequest, len(records))
+	for i := range records {
+		rdata, err := buildRData(&records[i])
+		if err != nil {
+			return fmt.Errorf("Failed to create Dyn RData: %s", err)
+		}
+		requests[i] = dynect.RecordRequest{
+			RData: rdata,
+			TTL:   records[i].TTL,
+		}
+	}
+
+	url := fmt.Sprintf("%sRecord/%s/%s/", recordType, zone, fqdn)
+	data := recordSetRequest{recordType + "Records": requests}
+	return c.DoContext(ctx, "PUT", url, data, nil)
+}
+
+// DeleteRecordSet stages the deletion of all the records of a type at fqdn.
+func (c *Client) DeleteRecordSet(ctx context.Context, zone, fqdn, recordType string) error {
+	url := fmt.Sprintf("%sRecord/%s/%s/", recordType, zone, fqdn)
+	return c.DoContext(ctx, "DELETE", url, nil, nil)
+}
+
+// recordFilter selects the records of a zone that Terraform leaves alone
+// when it manages the zone authoritatively.
+type recordFilter struct {
+	// Types are excluded record types. SOA records are always excluded.
+	Types []string
+
+	// ApexNS excludes the NS records at the zone apex, which Dyn manages.
+	ApexNS bool
+
+	// Names are path.Match patterns of excluded names, relative to the
+	// zone, "@" standing for the apex.
+	Names []string
+}
+
+// Excluded reports whether the record of the given type and name, relative
+// to the zone, is left alone.
+func (f *recordFilter) Excluded(recordType, name string) bool {
+	if recordType == "SOA" || (f.ApexNS && recordType == "NS" && name == "") {
+		return true
+	}
+	for _, t := range f.Types {
+		if strings.EqualFold(t, recordType) {
+			return true
+		}
+	}
+	if name == "" {
+		name = "@"
+	}
+	for _, pattern := range f.Names {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// recordSetKey identifies the records of a type at a node.
+type recordSetKey struct {
+	Type string
+	Name string
+}
+
+// groupRecordSets groups records by type and node, with their values in
+// canonical form.
+func groupRecordSets(records []dynect.Record) map[recordSetKey][]dynect.Record {
+	sets := make(map[recordSetKey][]dynect.Record)
+	for _, r := range records {
+		key := recordSetKey{Type: r.Type, Name: strings.ToLower(r.Name)}
+		r.Value = normalizeRecordValue(r.Type, r.Value)
+		sets[key] = append(sets[key], r)
+	}
+	return sets
+}
+
+// sameRecordSet reports whether two sets of records of the same type and
+// node have the same values and TTLs.
+func sameRecordSet(a, b []dynect.Record) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return strings.Join(recordSetContents(a), "\n") == strings.Join(recordSetContents(b), "\n")
+}
+
+func recordSetContents(records []dynect.Record) []string {
+	contents := make([]string, len(records))
+	for i, r := range records {
+		contents[i] = fmt.Sprintf("%s %s", r.TTL, normalizeRecordValue(r.Type, r.Value))
+	}
+	sort.Strings(contents)
+	return contents
+}
+
+// recordSetChange replaces the records of a type at a node with Records,
+// or deletes them when Records is empty.
+type recordSetChange struct {
+	Key     recordSetKey
+	Records []dynect.Record
+}
+
+// diffRecordSets lists the changes that turn the live records of a zone into
+// the desired ones, in a stable order.
+func diffRecordSets(live, desired []dynect.Record) []recordSetChange {
+	liveSets := groupRecordSets(live)
+	desiredSets := groupRecordSets(desired)
+
+	var changes []recordSetChange
+	for key, records := range desiredSets {
+		if !sameRecordSet(liveSets[key], records) {
+			changes = append(changes, recordSetChange{Key: key, Records: records})
+		}
+	}
+	for key := range liveSets {
+		if _, ok := desiredSets[key]; !ok {
+			changes = append(changes, recordSetChange{Key: key})
+		}
+	}
+
+	// deletions first, so that a CNAME can take the place of other records
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if (len(a.Records) == 0) != (len(b.Records) == 0) {
+			return len(a.Records) == 0
+		}
+		if a.Key.Name != b.Key.Name {
+			return a.Key.Name < b.Key.Name
+		}
+		return a.Key.Type < b.Key.Type
+	})
+	return changes
+}
+
+// stageRecordSetChanges stages changes on zone.
+func stageRecordSetChanges(ctx context.Context, client *Client, zone string, changes []recordSetChange) error {
+	for _, c := range changes {
+		fqdn := recordFQDN(c.Key.Name, zone)
+		if len(c.Records) == 0 {
+			log.Printf("[INFO] Deleting Dyn %s records of %s", c.Key.Type, fqdn)
+			if err := client.DeleteRecordSet(ctx, zone, fqdn, c.Key.Type); err != nil {
+				return fmt.Errorf("Failed to delete Dyn %s records of %s: %s", c.Key.Type, fqdn, err)
+			}
+			continue
+		}
+		log.Printf("[INFO] Replacing Dyn %s records of %s with %d records", c.Key.Type, fqdn, len(c.Records))
+		if err := client.ReplaceRecordSet(ctx, zone, fqdn, c.Key.Type, c.Records); err != nil {
+			return fmt.Errorf("Failed to replace Dyn %s records of %s: %s", c.Key.Type, fqdn, err)
+		}
+	}
+	return nil
+}
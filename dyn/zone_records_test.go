@@ -0,0 +1,130 @@
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/nesv/go-dynect/dynect"
+)
+
+func TestGetZoneRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/AllRecord/example.com" || r.URL.Query().Get("detail") != "Y" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {
+			"a_records": [{"zone": "example.com", "fqdn": "www.example.com", "record_type": "A", "record_id": 1, "ttl": 300, "rdata": {"address": "192.168.0.10"}}],
+			"mx_records": [{"zone": "example.com", "fqdn": "example.com", "record_type": "MX", "record_id": 2, "ttl": 3600, "rdata": {"preference": 10, "exchange": "mx.example.com."}}],
+			"dnskey_records": [{"zone": "example.com", "fqdn": "example.com", "record_type": "DNSKEY", "record_id": 4, "ttl": 3600, "rdata": {"flags": 257, "protocol": 3, "algorithm": 8, "public_key": "AwEAAa=="}}],
+			"unknown_records": [{"zone": "example.com", "fqdn": "example.com", "record_type": "UNKNOWN", "record_id": 3, "ttl": 3600, "rdata": {}}]
+		}}`)
+	}))
+	defer server.Close()
+
+	records, err := testClient(server).GetZoneRecords(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var actual []string
+	for _, r := range records {
+		actual = append(actual, fmt.Sprintf("%s %q %s %s %s", r.ID, r.Name, r.Type, r.TTL, r.Value))
+	}
+	sort.Strings(actual)
+
+	expected := `1 "www" A 300 192.168.0.10,2 "" MX 3600 10 mx.example.com.,4 "" DNSKEY 3600 257 3 8 AwEAAa==`
+	if strings.Join(actual, ",") != expected {
+		t.Fatalf("expected %q, got %q", expected, strings.Join(actual, ","))
+	}
+}
+
+func TestReplaceRecordSet(t *testing.T) {
+	var request string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		request = r.Method + " " + r.URL.Path + " " + string(body)
+		fmt.Fprint(w, `{"status": "success", "data": []}`)
+	}))
+	defer server.Close()
+
+	records := []dynect.Record{
+		{Type: "A", TTL: "300", Value: "192.168.0.10"},
+		{Type: "A", TTL: "300", Value: "192.168.0.11"},
+	}
+	if err := testClient(server).ReplaceRecordSet(context.Background(), "example.com", "www.example.com", "A", records); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := `PUT /REST/ARecord/example.com/www.example.com/ {"ARecords":[{"rdata":{"address":"192.168.0.10"},"ttl":"300"},{"rdata":{"address":"192.168.0.11"},"ttl":"300"}]}`
+	if request != expected {
+		t.Fatalf("expected %q, got %q", expected, request)
+	}
+}
+
+func TestRecordFilter(t *testing.T) {
+	filter := &recordFilter{
+		Types:  []string{"txt"},
+		ApexNS: true,
+		Names:  []string{"_acme-challenge*", "legacy.*", "@"},
+	}
+
+	cases := []struct {
+		recordType string
+		name       string
+		excluded   bool
+	}{
+		{"SOA", "", true},
+		{"NS", "", true},
+		{"NS", "dev", false},
+		{"TXT", "www", true},
+		{"A", "_acme-challenge.www", true},
+		{"A", "legacy.app", true},
+		{"A", "legacy", false},
+		{"MX", "", true},
+		{"A", "www", false},
+	}
+
+	for _, tc := range cases {
+		if actual := filter.Excluded(tc.recordType, tc.name); actual != tc.excluded {
+			t.Errorf("%s %q: expected excluded to be %t", tc.recordType, tc.name, tc.excluded)
+		}
+	}
+
+	if (&recordFilter{}).Excluded("NS", "") {
+		t.Fatal("expected apex NS records to be managed when ApexNS is false")
+	}
+}
+
+func TestDiffRecordSets(t *testing.T) {
+	live := []dynect.Record{
+		{Name: "www", Type: "A", TTL: "300", Value: "192.168.0.10"},
+		{Name: "www", Type: "A", TTL: "300", Value: "192.168.0.11"},
+		{Name: "mail", Type: "A", TTL: "300", Value: "192.168.0.20"},
+		{Name: "old", Type: "CNAME", TTL: "300", Value: "www.example.com."},
+		{Name: "api", Type: "AAAA", TTL: "300", Value: "2001:db8::1"},
+	}
+	desired := []dynect.Record{
+		{Name: "www", Type: "A", TTL: "300", Value: "192.168.0.11"},
+		{Name: "WWW", Type: "A", TTL: "300", Value: "192.168.0.10"},
+		{Name: "mail", Type: "A", TTL: "600", Value: "192.168.0.20"},
+		{Name: "api", Type: "AAAA", TTL: "300", Value: "2001:0db8:0000:0000:0000:0000:0000:0001"},
+		{Name: "new", Type: "TXT", TTL: "300", Value: "hello"},
+	}
+
+	var actual []string
+	for _, c := range diffRecordSets(live, desired) {
+		actual = append(actual, fmt.Sprintf("%s %s %d", c.Key.Type, c.Key.Name, len(c.Records)))
+	}
+
+	expected := "CNAME old 0,A mail 1,TXT new 1"
+	if strings.Join(actual, ",") != expected {
+		t.Fatalf("expected changes %q, got %q", expected, strings.Join(actual, ","))
+	}
+}
@@ -0,0 +1,392 @@
+package dyn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testZoneServer fakes the ZoneChanges and Zone endpoints of example.com and
+// records the requests made to them.
+type testZoneServer struct {
+	missing     bool
+	pending     string
+	publishCode int
+	publishBody string
+	requests    []string
+}
+
+func (s *testZoneServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/REST/"))
+
+	switch {
+	case s.missing && r.URL.Path == "/REST/ZoneChanges/example.com":
+		w.WriteHeader(http.StatusNotFound)
+		s.missing = false
+	case r.Method == "GET" && r.URL.Path == "/REST/ZoneChanges/example.com":
+		fmt.Fprintf(w, `{"status": "success", "data": [%s]}`, s.pending)
+	case r.Method == "DELETE" && r.URL.Path == "/REST/ZoneChanges/example.com":
+		fmt.Fprint(w, `{"status": "success", "data": {}}`)
+	case r.Method == "PUT" && r.URL.Path == "/REST/Zone/example.com":
+		body, _ := ioutil.ReadAll(r.Body)
+		s.publishBody = string(body)
+		if s.publishCode != 0 {
+			w.WriteHeader(s.publishCode)
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "serial": 2}}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testPublishZoneChanges(s *testZoneServer, stage func() error) error {
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	return publishZoneChanges(context.Background(), testClient(server), "example.com", "", stage)
+}
+
+func TestPublishZoneChanges(t *testing.T) {
+	s := &testZoneServer{}
+	staged := false
+
+	err := testPublishZoneChanges(s, func() error {
+		staged = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !staged {
+		t.Fatal("expected the changes to be staged")
+	}
+
+	expected := "GET ZoneChanges/example.com,PUT Zone/example.com"
+	if actual := strings.Join(s.requests, ","); actual != expected {
+		t.Fatalf("expected requests %q, got %q", expected, actual)
+	}
+	if expected := `{"publish":true}`; s.publishBody != expected {
+		t.Fatalf("expected publish body %s, got %s", expected, s.publishBody)
+	}
+}
+
+func TestPublishZoneChanges_notes(t *testing.T) {
+	s := &testZoneServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	err := publishZoneChanges(context.Background(), testClient(server), "example.com", "Terraform: dyn_record A www.example.com", func() error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := `{"publish":true,"notes":"Terraform: dyn_record A www.example.com"}`
+	if s.publishBody != expected {
+		t.Fatalf("expected publish body %s, got %s", expected, s.publishBody)
+	}
+}
+
+func TestPublishZoneChanges_pendingChanges(t *testing.T) {
+	s := &testZoneServer{
+		pending: `{"id": 1, "user_id": 42, "zone": "example.com", "fqdn": "www.example.com", "rdata_type": "A", "ttl": 3600}`,
+	}
+
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	client := testClient(server)
+	client.ForeignChanges = ForeignChangesFail
+
+	err := publishZoneChanges(context.Background(), client, "example.com", "", func() error {
+		t.Fatal("no change should be staged while the zone has pending changes")
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 unpublished changes") {
+		t.Fatalf("expected an error about the pending changes, got: %v", err)
+	}
+}
+
+func TestPublishZoneChanges_newZone(t *testing.T) {
+	// the zone is created by the staged changes, as from a zone file
+	s := &testZoneServer{missing: true}
+
+	err := testPublishZoneChanges(s, func() error { return nil })
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := "GET ZoneChanges/example.com,PUT Zone/example.com"
+	if actual := strings.Join(s.requests, ","); actual != expected {
+		t.Fatalf("expected requests %q, got %q", expected, actual)
+	}
+}
+
+func TestPublishZoneChanges_stageFailure(t *testing.T) {
+	s := &testZoneServer{}
+
+	err := testPublishZoneChanges(s, func() error {
+		return errors.New("Failed to create Dyn record")
+	})
+	if err == nil || err.Error() != "Failed to create Dyn record" {
+		t.Fatalf("expected the staging error, got: %v", err)
+	}
+
+	expected := "GET ZoneChanges/example.com,DELETE ZoneChanges/example.com"
+	if actual := strings.Join(s.requests, ","); actual != expected {
+		t.Fatalf("expected requests %q, got %q", expected, actual)
+	}
+}
+
+func TestPublishZoneChanges_publishFailure(t *testing.T) {
+	s := &testZoneServer{publishCode: http.StatusBadRequest}
+
+	err := testPublishZoneChanges(s, func() error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "Failed to publish Dyn zone") {
+		t.Fatalf("expected a publish error, got: %v", err)
+	}
+
+	expected := "GET ZoneChanges/example.com,PUT Zone/example.com,DELETE ZoneChanges/example.com"
+	if actual := strings.Join(s.requests, ","); actual != expected {
+		t.Fatalf("expected requests %q, got %q", expected, actual)
+	}
+}
+
+func TestPublishZoneChanges_foreignChangesWarn(t *testing.T) {
+	for _, policy := range []string{ForeignChangesWarn, ForeignChangesProceed} {
+		s := &testZoneServer{
+			pending: `{"id": 1, "user_id": 42, "zone": "example.com", "fqdn": "www.example.com", "rdata_type": "A", "ttl": 3600}`,
+		}
+		server := httptest.NewServer(s)
+
+		client := testClient(server)
+		client.ForeignChanges = policy
+
+		staged := false
+		err := publishZoneChanges(context.Background(), client, "example.com", "", func() error {
+			staged = true
+			return nil
+		})
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("%s: err: %s", policy, err)
+		}
+		if !staged {
+			t.Fatalf("%s: expected the changes to be staged", policy)
+		}
+	}
+}
+
+func TestPublishZoneChanges_foreignChangesFailure(t *testing.T) {
+	s := &testZoneServer{
+		pending:     `{"id": 1, "user_id": 42, "zone": "example.com", "fqdn": "www.example.com", "rdata_type": "A", "ttl": 3600}`,
+		publishCode: http.StatusBadRequest,
+	}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	client := testClient(server)
+	client.ForeignChanges = ForeignChangesWarn
+
+	err := publishZoneChanges(context.Background(), client, "example.com", "", func() error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "nothing was discarded") || !strings.Contains(err.Error(), "A www.example.com") {
+		t.Fatalf("expected an error listing the pending changes, got: %v", err)
+	}
+
+	expected := "GET ZoneChanges/example.com,PUT Zone/example.com,GET ZoneChanges/example.com"
+	if actual := strings.Join(s.requests, ","); actual != expected {
+		t.Fatalf("expected requests %q, got %q", expected, actual)
+	}
+}
+
+func TestDescribeZoneChange(t *testing.T) {
+	c := ZoneChange{ID: 7, UserID: 42, FQDN: "www.example.com", RDataType: "CNAME", TTL: 300}
+
+	expected := "CNAME www.example.com (ttl 300, user 42, change 7)"
+	if actual := describeZoneChange(c); actual != expected {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestGetZoneNotes(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		fmt.Fprint(w, `{"status": "success", "data": [
+			{"zone": "example.com", "serial": 3, "type": "publish", "user_name": "terraform", "timestamp": "1539907200", "note": "Terraform: dyn_record A www.example.com"},
+			{"zone": "example.com", "serial": "2", "type": "publish", "user_name": "portal", "timestamp": 1539820800, "note": ""}
+		]}`)
+	}))
+	defer server.Close()
+
+	notes, err := testClient(server).GetZoneNotes(context.Background(), "example.com", 2, 0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if expected := `{"zone":"example.com","limit":2}`; body != expected {
+		t.Fatalf("expected request body %s, got %s", expected, body)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Serial != "3" || notes[1].Serial != "2" || notes[1].Timestamp != "1539820800" {
+		t.Fatalf("expected numbers and strings to be decoded alike, got %#v", notes)
+	}
+	if notes[0].Note != "Terraform: dyn_record A www.example.com" {
+		t.Fatalf("unexpected note %q", notes[0].Note)
+	}
+}
+
+func TestPublishZoneChanges_manualPublish(t *testing.T) {
+	s := &testZoneServer{
+		pending: `{"id": 1, "user_id": 42, "zone": "example.com", "fqdn": "www.example.com", "rdata_type": "A", "ttl": 3600}`,
+	}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	client := testClient(server)
+	client.ManualPublishZones = []string{"Example.com."}
+
+	staged := false
+	err := publishZoneChanges(context.Background(), client, "example.com", "", func() error {
+		staged = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !staged {
+		t.Fatal("expected the changes to be staged")
+	}
+	if len(s.requests) != 0 {
+		t.Fatalf("expected the zone to be left alone, got requests %q", s.requests)
+	}
+
+	err = publishZoneChanges(context.Background(), client, "example.com", "", func() error {
+		return errors.New("Failed to create Dyn record")
+	})
+	if err == nil || len(s.requests) != 0 {
+		t.Fatalf("expected the staging error without discarding pending changes, got %v and requests %q", err, s.requests)
+	}
+}
+
+func TestAutoPublishes(t *testing.T) {
+	client := NewClient("terraform")
+	client.ManualPublishZones = []string{"secure.example.com"}
+
+	if !client.autoPublishes("example.com") {
+		t.Fatal("expected example.com to be published automatically")
+	}
+	if client.autoPublishes("secure.example.com.") {
+		t.Fatal("expected secure.example.com to be published manually")
+	}
+
+	client.AutoPublish = false
+	if client.autoPublishes("example.com") {
+		t.Fatal("expected every zone to be published manually")
+	}
+}
+
+func TestHasPendingChange(t *testing.T) {
+	s := &testZoneServer{
+		pending: `{"id": 1, "user_id": 42, "zone": "example.com", "fqdn": "WWW.example.com", "rdata_type": "A", "ttl": 3600}`,
+	}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	client := testClient(server)
+	cases := []struct {
+		fqdn       string
+		recordType string
+		expected   bool
+	}{
+		{"www.example.com", "A", true},
+		{"www.example.com.", "A", true},
+		{"www.example.com", "AAAA", false},
+		{"mail.example.com", "A", false},
+	}
+
+	for _, tc := range cases {
+		actual, err := client.HasPendingChange(context.Background(), "example.com", tc.fqdn, tc.recordType)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if actual != tc.expected {
+			t.Errorf("%s %s: expected %t, got %t", tc.recordType, tc.fqdn, tc.expected, actual)
+		}
+	}
+}
+
+func TestPublishZoneChanges_frozen(t *testing.T) {
+	s := &testZoneServer{}
+
+	err := testPublishZoneChanges(s, func() error {
+		return errors.New(`Failed to create Dyn record: server responded with 400 Bad Request: {"status": "failure", "msgs": [{"INFO": "zone: Zone is frozen", "ERR_CD": "OPERATION_FAILED"}]}`)
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "Dyn zone example.com is frozen") {
+		t.Fatalf("expected an error about the zone being frozen, got: %v", err)
+	}
+}
+
+func TestFreezeZone(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+string(b))
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"status": "success", "data": {"zone": "example.com", "serial": 3, "frozen": true}}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {}}`)
+	}))
+	defer server.Close()
+
+	client := testClient(server)
+	ctx := context.Background()
+	if err := client.FreezeZone(ctx, "example.com"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	zone, err := client.GetZone(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if zone.Frozen == nil || !*zone.Frozen || zone.Serial != 3 {
+		t.Fatalf("expected a frozen zone at serial 3, got %#v", zone)
+	}
+	if err := client.ThawZone(ctx, "example.com"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := `PUT /REST/Zone/example.com {"freeze":true},GET /REST/Zone/example.com ,PUT /REST/Zone/example.com {"thaw":true}`
+	if actual := strings.Join(bodies, ","); actual != expected {
+		t.Fatalf("expected requests %q, got %q", expected, actual)
+	}
+}
+
+func TestGetZoneDefaultTTL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/REST/SOARecord/example.com/example.com/" || r.URL.Query().Get("detail") != "Y" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"status": "success", "data": [{"zone": "example.com", "fqdn": "example.com", "record_type": "SOA", "record_id": 1, "ttl": 1800,
+			"rdata": {"mname": "ns1.p01.dynect.net.", "rname": "hostmaster.example.com.", "serial": 3, "refresh": 3600, "retry": 600, "expire": 604800, "minimum": 1800}}]}`)
+	}))
+	defer server.Close()
+
+	ttl, err := testClient(server).GetZoneDefaultTTL(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ttl != 1800 {
+		t.Fatalf("expected a default TTL of 1800, got %d", ttl)
+	}
+}
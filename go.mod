@@ -1,6 +1,7 @@
 module github.com/terraform-providers/terraform-provider-dyn
 
 require (
+	github.com/hashicorp/go-hclog v0.0.0-20181001195459-61d530d6c27f
 	github.com/hashicorp/terraform v0.12.4
 	github.com/nesv/go-dynect v0.5.3
 )